@@ -42,18 +42,38 @@ func main() {
 	}
 
 	// Initialize services
-	gameService := services.NewGameService(db)
+	sideBets := services.NewSideBetRegistry(
+		services.NewBestNineBet(db, cfg.BestNineBuyIn),
+		services.NewPuttPuttPokerBet(db, services.PokerStakes{
+			BuyIn:         cfg.PuttPuttPokerBuyIn,
+			PenaltyAmount: cfg.PuttPuttPokerPenalty,
+		}),
+		services.NewSkinsBet(db, cfg.SkinsValue),
+		services.NewNassauBet(db, cfg.NassauStake),
+		services.NewWolfBet(db),
+		services.NewBingoBangoBongoBet(db),
+		services.NewJunkBet(db, cfg.JunkPointValue),
+		services.NewSnakeBet(db, cfg.SnakePassValue),
+		services.NewVegasBet(db, cfg.VegasPointValue),
+	)
+	gameService := services.NewGameService(db, sideBets)
 	playerService := services.NewPlayerService(db)
-	scoreService := services.NewScoreService(db)
-	sideBetService := services.NewSideBetService(db)
+	sideBetService := services.NewSideBetService(db, sideBets)
+	scoreService := services.NewScoreService(db, sideBetService)
+	teamService := services.NewTeamService(db)
+	settlementService := services.NewSettlementService(db, sideBets)
+	golferService := services.NewGolferService(db)
 	websocketService := services.NewWebSocketService()
 
 	// Initialize handlers
 	gameHandler := handlers.NewGameHandler(gameService, websocketService)
-	playerHandler := handlers.NewPlayerHandler(playerService, websocketService)
-	scoreHandler := handlers.NewScoreHandler(scoreService, sideBetService, websocketService)
+	playerHandler := handlers.NewPlayerHandler(playerService, scoreService, sideBetService, websocketService)
+	scoreHandler := handlers.NewScoreHandler(scoreService, sideBetService, teamService, websocketService)
 	sideBetHandler := handlers.NewSideBetHandler(sideBetService, websocketService)
-	spectatorHandler := handlers.NewSpectatorHandler(gameService)
+	teamHandler := handlers.NewTeamHandler(teamService, websocketService)
+	settlementHandler := handlers.NewSettlementHandler(settlementService, websocketService)
+	spectatorHandler := handlers.NewSpectatorHandler(gameService, scoreService)
+	golferHandler := handlers.NewGolferHandler(golferService)
 
 	// Setup router
 	r := chi.NewRouter()
@@ -91,8 +111,10 @@ func main() {
 				r.Use(middleware.GameAuth(db))
 				r.Get("/", gameHandler.GetGame)
 				r.Delete("/", gameHandler.DeleteGame)
+				r.Put("/stakes", gameHandler.UpdateStakes)
 				r.Post("/start", gameHandler.StartGame)
 				r.Post("/complete", gameHandler.CompleteGame)
+				r.Put("/playoffs/{result}", gameHandler.RecordPlayoff)
 
 				// Player management
 				r.Route("/players", func(r chi.Router) {
@@ -112,19 +134,38 @@ func main() {
 					})
 				})
 
+				// Team routes
+				r.Route("/teams", func(r chi.Router) {
+					r.Get("/", teamHandler.GetTeams)
+
+					r.Route("/{teamId}/scores", func(r chi.Router) {
+						r.Post("/", teamHandler.RecordTeamScore)
+						r.Put("/{hole}", teamHandler.UpdateTeamScore)
+					})
+				})
+
 				// Game data routes
 				r.Get("/scorecard", scoreHandler.GetGameScorecard)
 				r.Get("/leaderboard", scoreHandler.GetLeaderboard)
+				r.Get("/projections", scoreHandler.GetProjections)
 
 				// Side bet routes
-				r.Route("/side-bets", func(r chi.Router) {
-					r.Get("/best-nine", sideBetHandler.GetBestNineStandings)
-					r.Get("/putt-putt-poker", sideBetHandler.GetPuttPuttPokerStatus)
-					r.Post("/putt-putt-poker/deal", sideBetHandler.DealPokerCards)
+				r.Route("/side-bets", sideBetHandler.Routes)
+
+				// Settlement routes
+				r.Route("/settlement", func(r chi.Router) {
+					r.Get("/", settlementHandler.GetSettlement)
+					r.Put("/transfers/{transferId}", settlementHandler.UpdateTransfer)
 				})
 			})
 		})
 
+		// Golfer handicap records
+		r.Route("/golfers", func(r chi.Router) {
+			r.Post("/", golferHandler.CreateGolfer)
+			r.Get("/{golferId}", golferHandler.GetGolfer)
+		})
+
 		// Spectator routes
 		r.Route("/spectate", func(r chi.Router) {
 			r.Get("/{spectatorToken}", spectatorHandler.SpectateGame)
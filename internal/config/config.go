@@ -13,6 +13,28 @@ type Config struct {
 	DatabaseURL string
 	CORSOrigins []string
 	LogLevel    string
+
+	// Best Nine buy-in per player in dollars
+	BestNineBuyIn float64
+
+	// Putt Putt Poker stakes in dollars
+	PuttPuttPokerBuyIn   float64
+	PuttPuttPokerPenalty float64
+
+	// Dollar value of a single skin
+	SkinsValue float64
+
+	// Default Nassau stake for each match and press
+	NassauStake float64
+
+	// Default dollar value of a junk point
+	JunkPointValue float64
+
+	// Dollars added to the snake pot each time it changes hands
+	SnakePassValue float64
+
+	// Default dollar value of a Vegas point
+	VegasPointValue float64
 }
 
 // Load loads configuration from environment variables with sensible defaults
@@ -27,7 +49,15 @@ func Load() *Config {
 			"http://localhost:8080",
 			"https://golfgamez.com",
 		}),
-		LogLevel: getEnv("LOG_LEVEL", "info"),
+		LogLevel:             getEnv("LOG_LEVEL", "info"),
+		BestNineBuyIn:        getEnvAsFloat("BEST_NINE_BUY_IN", 5.00),
+		PuttPuttPokerBuyIn:   getEnvAsFloat("PUTT_PUTT_POKER_BUY_IN", 5.00),
+		PuttPuttPokerPenalty: getEnvAsFloat("PUTT_PUTT_POKER_PENALTY", 1.00),
+		SkinsValue:           getEnvAsFloat("SKINS_VALUE", 1.00),
+		NassauStake:          getEnvAsFloat("NASSAU_STAKE", 5.00),
+		JunkPointValue:       getEnvAsFloat("JUNK_POINT_VALUE", 1.00),
+		SnakePassValue:       getEnvAsFloat("SNAKE_PASS_VALUE", 1.00),
+		VegasPointValue:      getEnvAsFloat("VEGAS_POINT_VALUE", 0.25),
 	}
 
 	return cfg
@@ -51,6 +81,16 @@ func getEnvAsInt(key string, defaultVal int) int {
 	return defaultVal
 }
 
+// getEnvAsFloat gets an environment variable as float or returns a default value
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultVal
+}
+
 // getEnvAsSlice gets an environment variable as slice or returns a default value
 func getEnvAsSlice(key string, defaultVal []string) []string {
 	if value := os.Getenv(key); value != "" {
@@ -242,5 +242,492 @@ func getMigrations() []Migration {
 				('hole_dr_18', 'diamond-run', 18, 4, 5);
 			`,
 		},
+		{
+			Version: "003",
+			Name:    "Align side bet type constraint with API bet types",
+			SQL: `
+				-- SQLite cannot alter a CHECK constraint, so rebuild the table
+				CREATE TABLE side_bet_calculations_new (
+					id TEXT PRIMARY KEY,
+					game_id TEXT NOT NULL,
+					player_id TEXT NOT NULL,
+					bet_type TEXT NOT NULL CHECK (bet_type IN ('best-nine', 'putt-putt-poker')),
+					calculation_data TEXT NOT NULL, -- JSON
+					current_position INTEGER,
+					final_position INTEGER,
+					is_winner BOOLEAN DEFAULT 0,
+					calculated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+					UNIQUE(player_id, bet_type)
+				);
+
+				INSERT INTO side_bet_calculations_new
+				SELECT id, game_id, player_id, REPLACE(bet_type, '_', '-'), calculation_data,
+				       current_position, final_position, is_winner, calculated_at
+				FROM side_bet_calculations;
+
+				DROP TABLE side_bet_calculations;
+				ALTER TABLE side_bet_calculations_new RENAME TO side_bet_calculations;
+
+				CREATE INDEX idx_sidebet_game_bet ON side_bet_calculations(game_id, bet_type);
+				CREATE INDEX idx_sidebet_player_bet ON side_bet_calculations(player_id, bet_type);
+			`,
+		},
+		{
+			Version: "004",
+			Name:    "Allow reversal entries in putt putt poker card ledger",
+			SQL: `
+				CREATE TABLE putt_putt_poker_cards_new (
+					id TEXT PRIMARY KEY,
+					player_id TEXT NOT NULL,
+					game_id TEXT NOT NULL,
+					hole INTEGER,
+					action TEXT NOT NULL CHECK (action IN ('starting', 'one_putt', 'hole_in_one', 'penalty', 'reversal')),
+					cards_change INTEGER NOT NULL,
+					penalty_amount REAL,
+					total_cards INTEGER NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+				);
+
+				INSERT INTO putt_putt_poker_cards_new SELECT * FROM putt_putt_poker_cards;
+
+				DROP TABLE putt_putt_poker_cards;
+				ALTER TABLE putt_putt_poker_cards_new RENAME TO putt_putt_poker_cards;
+
+				CREATE INDEX idx_poker_cards_player ON putt_putt_poker_cards(player_id);
+				CREATE INDEX idx_poker_cards_game ON putt_putt_poker_cards(game_id);
+				CREATE INDEX idx_poker_cards_hole ON putt_putt_poker_cards(hole);
+			`,
+		},
+		{
+			Version: "005",
+			Name:    "Create poker seed commitments table",
+			SQL: `
+				-- The seed hash is published when the game starts and the seed
+				-- revealed when the final cards are dealt
+				CREATE TABLE poker_seeds (
+					game_id TEXT PRIMARY KEY,
+					seed TEXT NOT NULL,
+					seed_hash TEXT NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					revealed_at TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+				);
+			`,
+		},
+		{
+			Version: "006",
+			Name:    "Remove side bet type constraint for registered side bets",
+			SQL: `
+				-- Valid bet types are defined by the side bet registry
+				CREATE TABLE side_bet_calculations_new (
+					id TEXT PRIMARY KEY,
+					game_id TEXT NOT NULL,
+					player_id TEXT NOT NULL,
+					bet_type TEXT NOT NULL,
+					calculation_data TEXT NOT NULL, -- JSON
+					current_position INTEGER,
+					final_position INTEGER,
+					is_winner BOOLEAN DEFAULT 0,
+					calculated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE,
+					UNIQUE(player_id, bet_type)
+				);
+
+				INSERT INTO side_bet_calculations_new
+				SELECT id, game_id, player_id, bet_type, calculation_data,
+				       current_position, final_position, is_winner, calculated_at
+				FROM side_bet_calculations;
+
+				DROP TABLE side_bet_calculations;
+				ALTER TABLE side_bet_calculations_new RENAME TO side_bet_calculations;
+
+				CREATE INDEX idx_sidebet_game_bet ON side_bet_calculations(game_id, bet_type);
+				CREATE INDEX idx_sidebet_player_bet ON side_bet_calculations(player_id, bet_type);
+			`,
+		},
+		{
+			Version: "007",
+			Name:    "Create Nassau settings and presses tables",
+			SQL: `
+				CREATE TABLE nassau_settings (
+					game_id TEXT PRIMARY KEY,
+					side_a TEXT NOT NULL, -- JSON array of player IDs
+					side_b TEXT NOT NULL, -- JSON array of player IDs
+					stake REAL NOT NULL,
+					auto_press BOOLEAN NOT NULL DEFAULT 1,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+				);
+
+				-- Manual presses only; automatic presses are derived from scores
+				CREATE TABLE nassau_presses (
+					id TEXT PRIMARY KEY,
+					game_id TEXT NOT NULL,
+					segment TEXT NOT NULL CHECK (segment IN ('front', 'back', 'overall')),
+					side TEXT NOT NULL CHECK (side IN ('a', 'b')),
+					start_hole INTEGER NOT NULL CHECK (start_hole >= 1 AND start_hole <= 18),
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					UNIQUE(game_id, segment, start_hole)
+				);
+
+				CREATE INDEX idx_nassau_presses_game ON nassau_presses(game_id);
+			`,
+		},
+		{
+			Version: "008",
+			Name:    "Create wolf choices table",
+			SQL: `
+				CREATE TABLE wolf_choices (
+					game_id TEXT NOT NULL,
+					hole INTEGER NOT NULL CHECK (hole >= 1 AND hole <= 18),
+					wolf_id TEXT NOT NULL,
+					choice TEXT NOT NULL CHECK (choice IN ('partner', 'lone', 'blind')),
+					partner_id TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (game_id, hole),
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (wolf_id) REFERENCES players(id) ON DELETE CASCADE,
+					FOREIGN KEY (partner_id) REFERENCES players(id) ON DELETE CASCADE
+				);
+			`,
+		},
+		{
+			Version: "009",
+			Name:    "Add scoring format to games",
+			SQL: `
+				ALTER TABLE games ADD COLUMN scoring_format TEXT NOT NULL DEFAULT 'stroke-play';
+				ALTER TABLE games ADD COLUMN stableford_points TEXT; -- JSON point table for Stableford formats
+			`,
+		},
+		{
+			Version: "010",
+			Name:    "Create teams and team scores tables",
+			SQL: `
+				ALTER TABLE games ADD COLUMN team_format TEXT CHECK (team_format IN ('best-ball', 'scramble', 'shamble'));
+
+				CREATE TABLE teams (
+					id TEXT PRIMARY KEY,
+					game_id TEXT NOT NULL,
+					name TEXT NOT NULL,
+					position INTEGER NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					UNIQUE(game_id, name)
+				);
+
+				ALTER TABLE players ADD COLUMN team_id TEXT REFERENCES teams(id) ON DELETE SET NULL;
+
+				-- Scramble teams record one score per hole
+				CREATE TABLE team_scores (
+					id TEXT PRIMARY KEY,
+					team_id TEXT NOT NULL,
+					game_id TEXT NOT NULL,
+					hole INTEGER NOT NULL CHECK (hole >= 1 AND hole <= 18),
+					strokes INTEGER NOT NULL CHECK (strokes > 0),
+					putts INTEGER NOT NULL CHECK (putts >= 0),
+					par INTEGER NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP,
+					FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					UNIQUE(team_id, hole)
+				);
+
+				CREATE INDEX idx_teams_game ON teams(game_id);
+				CREATE INDEX idx_players_team ON players(team_id);
+				CREATE INDEX idx_team_scores_game ON team_scores(game_id);
+			`,
+		},
+		{
+			Version: "011",
+			Name:    "Create bingo bango bongo events table",
+			SQL: `
+				CREATE TABLE bingo_bango_bongo_events (
+					game_id TEXT NOT NULL,
+					hole INTEGER NOT NULL CHECK (hole >= 1 AND hole <= 18),
+					bingo_player_id TEXT,
+					bango_player_id TEXT,
+					bongo_player_id TEXT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP,
+					PRIMARY KEY (game_id, hole),
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (bingo_player_id) REFERENCES players(id) ON DELETE SET NULL,
+					FOREIGN KEY (bango_player_id) REFERENCES players(id) ON DELETE SET NULL,
+					FOREIGN KEY (bongo_player_id) REFERENCES players(id) ON DELETE SET NULL
+				);
+			`,
+		},
+		{
+			Version: "012",
+			Name:    "Create junk settings and events tables",
+			SQL: `
+				CREATE TABLE junk_settings (
+					game_id TEXT PRIMARY KEY,
+					items TEXT NOT NULL, -- JSON object of points by item
+					point_value REAL NOT NULL,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+				);
+
+				-- Hand-tagged items only; birdies, eagles and chip-ins are derived from scores
+				CREATE TABLE junk_events (
+					game_id TEXT NOT NULL,
+					hole INTEGER NOT NULL CHECK (hole >= 1 AND hole <= 18),
+					player_id TEXT NOT NULL,
+					item TEXT NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (game_id, hole, player_id, item),
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+				);
+			`,
+		},
+		{
+			Version: "013",
+			Name:    "Create Vegas settings table",
+			SQL: `
+				CREATE TABLE vegas_settings (
+					game_id TEXT PRIMARY KEY,
+					side_a TEXT NOT NULL, -- JSON array of player IDs
+					side_b TEXT NOT NULL, -- JSON array of player IDs
+					point_value REAL NOT NULL,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+				);
+			`,
+		},
+		{
+			Version: "014",
+			Name:    "Create settlement amounts and transfers tables",
+			SQL: `
+				CREATE TABLE settlement_amounts (
+					game_id TEXT NOT NULL,
+					player_id TEXT NOT NULL,
+					bet_type TEXT NOT NULL,
+					amount REAL NOT NULL,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (game_id, player_id, bet_type),
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE
+				);
+
+				CREATE TABLE settlement_transfers (
+					id TEXT PRIMARY KEY,
+					game_id TEXT NOT NULL,
+					from_player_id TEXT NOT NULL,
+					to_player_id TEXT NOT NULL,
+					amount REAL NOT NULL CHECK (amount > 0),
+					paid BOOLEAN NOT NULL DEFAULT 0,
+					paid_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+					FOREIGN KEY (from_player_id) REFERENCES players(id) ON DELETE CASCADE,
+					FOREIGN KEY (to_player_id) REFERENCES players(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX idx_settlement_transfers_game ON settlement_transfers(game_id);
+			`,
+		},
+		{
+			Version: "015",
+			Name:    "Add stakes to games",
+			SQL: `
+				ALTER TABLE games ADD COLUMN stakes TEXT; -- JSON stakes for each side bet played for money
+			`,
+		},
+		{
+			Version: "016",
+			Name:    "Add side bet participation to players",
+			SQL: `
+				ALTER TABLE players ADD COLUMN side_bets TEXT; -- JSON array of side bets the player takes part in, NULL for every side bet in the game
+			`,
+		},
+		{
+			Version: "017",
+			Name:    "Add tiebreak policy to games",
+			SQL: `
+				ALTER TABLE games ADD COLUMN tiebreak TEXT NOT NULL DEFAULT 'card-playoff';
+			`,
+		},
+		{
+			Version: "018",
+			Name:    "Track handicap strokes per hole as a count",
+			SQL: `
+				-- Negative when a plus handicap gives a stroke back
+				ALTER TABLE scores ADD COLUMN handicap_strokes INTEGER NOT NULL DEFAULT 0;
+				UPDATE scores SET handicap_strokes = handicap_stroke;
+				ALTER TABLE scores DROP COLUMN handicap_stroke;
+			`,
+		},
+		{
+			Version: "019",
+			Name:    "Create tee sets and add tee and handicap allowance to games",
+			SQL: `
+				CREATE TABLE tee_sets (
+					id TEXT PRIMARY KEY,
+					course_name TEXT NOT NULL,
+					name TEXT NOT NULL,
+					course_rating REAL NOT NULL,
+					slope_rating INTEGER NOT NULL,
+					par INTEGER NOT NULL,
+					UNIQUE(course_name, name)
+				);
+
+				INSERT INTO tee_sets (id, course_name, name, course_rating, slope_rating, par) VALUES
+				('tee_dr_black', 'diamond-run', 'black', 73.4, 138, 71),
+				('tee_dr_blue', 'diamond-run', 'blue', 71.6, 133, 71),
+				('tee_dr_white', 'diamond-run', 'white', 69.8, 127, 71),
+				('tee_dr_gold', 'diamond-run', 'gold', 67.5, 119, 71);
+
+				ALTER TABLE games ADD COLUMN tee TEXT NOT NULL DEFAULT 'white';
+				-- Percentage of course handicap received, full handicap for existing games
+				ALTER TABLE games ADD COLUMN handicap_allowance INTEGER NOT NULL DEFAULT 100;
+			`,
+		},
+		{
+			Version: "020",
+			Name:    "Add max score rule and adjusted strokes",
+			SQL: `
+				ALTER TABLE games ADD COLUMN max_score TEXT NOT NULL DEFAULT 'net-double-bogey';
+				ALTER TABLE scores ADD COLUMN adjusted_strokes INTEGER NOT NULL DEFAULT 0;
+				UPDATE scores SET adjusted_strokes = MIN(strokes, par + 2 + handicap_strokes);
+			`,
+		},
+		{
+			Version: "021",
+			Name:    "Create golfers and handicap index history",
+			SQL: `
+				CREATE TABLE golfers (
+					id TEXT PRIMARY KEY,
+					name TEXT NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				-- Rounds keep their game ID after the game is deleted
+				CREATE TABLE handicap_rounds (
+					id TEXT PRIMARY KEY,
+					golfer_id TEXT NOT NULL REFERENCES golfers(id) ON DELETE CASCADE,
+					game_id TEXT NOT NULL,
+					played_at TIMESTAMP NOT NULL,
+					adjusted_gross INTEGER NOT NULL,
+					course_rating REAL NOT NULL,
+					slope_rating INTEGER NOT NULL,
+					differential REAL NOT NULL,
+					UNIQUE(golfer_id, game_id)
+				);
+
+				CREATE INDEX idx_handicap_rounds_golfer ON handicap_rounds(golfer_id, played_at);
+
+				CREATE TABLE handicap_index_history (
+					id TEXT PRIMARY KEY,
+					golfer_id TEXT NOT NULL REFERENCES golfers(id) ON DELETE CASCADE,
+					game_id TEXT, -- NULL for the starting index
+					handicap_index REAL NOT NULL,
+					low_index REAL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX idx_handicap_index_history_golfer ON handicap_index_history(golfer_id, created_at);
+
+				ALTER TABLE players ADD COLUMN golfer_id TEXT REFERENCES golfers(id);
+			`,
+		},
+		{
+			Version: "022",
+			Name:    "Rate tees and holes by gender and add player tees",
+			SQL: `
+				-- Tees are rated separately for men and women
+				CREATE TABLE tee_sets_by_gender (
+					id TEXT PRIMARY KEY,
+					course_name TEXT NOT NULL,
+					name TEXT NOT NULL,
+					gender TEXT NOT NULL,
+					course_rating REAL NOT NULL,
+					slope_rating INTEGER NOT NULL,
+					par INTEGER NOT NULL,
+					UNIQUE(course_name, name, gender)
+				);
+
+				INSERT INTO tee_sets_by_gender (id, course_name, name, gender, course_rating, slope_rating, par)
+				SELECT id, course_name, name, 'male', course_rating, slope_rating, par FROM tee_sets;
+
+				INSERT INTO tee_sets_by_gender (id, course_name, name, gender, course_rating, slope_rating, par) VALUES
+				('tee_dr_black_f', 'diamond-run', 'black', 'female', 79.6, 147, 73),
+				('tee_dr_blue_f', 'diamond-run', 'blue', 'female', 77.4, 141, 73),
+				('tee_dr_white_f', 'diamond-run', 'white', 'female', 75.1, 134, 73),
+				('tee_dr_gold_f', 'diamond-run', 'gold', 'female', 72.3, 126, 73);
+
+				DROP TABLE tee_sets;
+				ALTER TABLE tee_sets_by_gender RENAME TO tee_sets;
+
+				-- Par and stroke index can differ on the women's card
+				CREATE TABLE course_data_by_gender (
+					id TEXT PRIMARY KEY,
+					course_name TEXT NOT NULL,
+					gender TEXT NOT NULL,
+					hole INTEGER NOT NULL,
+					par INTEGER NOT NULL,
+					handicap_ranking INTEGER NOT NULL,
+					yardage INTEGER,
+					description TEXT,
+					UNIQUE(course_name, gender, hole)
+				);
+
+				INSERT INTO course_data_by_gender (id, course_name, gender, hole, par, handicap_ranking, yardage, description)
+				SELECT id, course_name, 'male', hole, par, handicap_ranking, yardage, description FROM course_data;
+
+				INSERT INTO course_data_by_gender (id, course_name, gender, hole, par, handicap_ranking) VALUES
+				('hole_dr_f01', 'diamond-run', 'female', 1, 4, 9),
+				('hole_dr_f02', 'diamond-run', 'female', 2, 3, 17),
+				('hole_dr_f03', 'diamond-run', 'female', 3, 5, 5),
+				('hole_dr_f04', 'diamond-run', 'female', 4, 4, 7),
+				('hole_dr_f05', 'diamond-run', 'female', 5, 3, 15),
+				('hole_dr_f06', 'diamond-run', 'female', 6, 4, 11),
+				('hole_dr_f07', 'diamond-run', 'female', 7, 5, 3),
+				('hole_dr_f08', 'diamond-run', 'female', 8, 3, 13),
+				('hole_dr_f09', 'diamond-run', 'female', 9, 5, 1),
+				('hole_dr_f10', 'diamond-run', 'female', 10, 4, 10),
+				('hole_dr_f11', 'diamond-run', 'female', 11, 3, 18),
+				('hole_dr_f12', 'diamond-run', 'female', 12, 5, 4),
+				('hole_dr_f13', 'diamond-run', 'female', 13, 3, 16),
+				('hole_dr_f14', 'diamond-run', 'female', 14, 4, 12),
+				('hole_dr_f15', 'diamond-run', 'female', 15, 5, 6),
+				('hole_dr_f16', 'diamond-run', 'female', 16, 4, 14),
+				('hole_dr_f17', 'diamond-run', 'female', 17, 5, 2),
+				('hole_dr_f18', 'diamond-run', 'female', 18, 4, 8);
+
+				DROP TABLE course_data;
+				ALTER TABLE course_data_by_gender RENAME TO course_data;
+				CREATE INDEX idx_course_data_course ON course_data(course_name, gender);
+
+				-- Players choose their own tee, starting from the game's
+				ALTER TABLE players ADD COLUMN tee TEXT NOT NULL DEFAULT 'white';
+				UPDATE players SET tee = (SELECT tee FROM games WHERE games.id = players.game_id);
+			`,
+		},
+		{
+			Version: "023",
+			Name:    "Record which bets a settlement covers",
+			SQL: `
+				CREATE TABLE settlement_bets (
+					game_id TEXT NOT NULL,
+					bet_type TEXT NOT NULL,
+					pending BOOLEAN NOT NULL DEFAULT 0,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (game_id, bet_type),
+					FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+				);
+
+				INSERT INTO settlement_bets (game_id, bet_type, pending)
+				SELECT DISTINCT game_id, bet_type, 0 FROM settlement_amounts;
+			`,
+		},
 	}
 }
\ No newline at end of file
@@ -77,6 +77,39 @@ func (h *GameHandler) GetGame(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(game)
 }
 
+// UpdateStakes handles PUT /games/{gameId}/stakes
+func (h *GameHandler) UpdateStakes(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+
+	var req models.Stakes
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiErr := errors.New(errors.ErrValidation, "Invalid JSON in request body")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	game, err := h.gameService.UpdateStakes(gameID, &req)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	// Broadcast stakes update
+	h.websocketService.BroadcastGameUpdate(gameID, "stakes_updated", game.Stakes)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(game)
+
+	log.Info().
+		Str("game_id", gameID).
+		Str("request_id", middleware.GetRequestID(r.Context())).
+		Msg("Game stakes updated via API")
+}
+
 // StartGame handles POST /games/{gameId}/start
 func (h *GameHandler) StartGame(w http.ResponseWriter, r *http.Request) {
 	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
@@ -130,6 +163,41 @@ func (h *GameHandler) CompleteGame(w http.ResponseWriter, r *http.Request) {
 		Msg("Game completed via API")
 }
 
+// RecordPlayoff handles PUT /games/{gameId}/playoffs/{result}
+func (h *GameHandler) RecordPlayoff(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+	result := chi.URLParam(r, "result")
+
+	var req models.PlayoffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiErr := errors.New(errors.ErrValidation, "Invalid JSON in request body")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	finalResults, err := h.gameService.RecordPlayoff(gameID, result, &req)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	// Broadcast final results update
+	h.websocketService.BroadcastGameUpdate(gameID, "final_results_updated", finalResults)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(finalResults)
+
+	log.Info().
+		Str("game_id", gameID).
+		Str("result", result).
+		Str("request_id", middleware.GetRequestID(r.Context())).
+		Msg("Playoff recorded via API")
+}
+
 // DeleteGame handles DELETE /games/{gameId}
 func (h *GameHandler) DeleteGame(w http.ResponseWriter, r *http.Request) {
 	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
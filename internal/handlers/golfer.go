@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golf-gamez/internal/middleware"
+	"golf-gamez/internal/models"
+	"golf-gamez/internal/services"
+	"golf-gamez/pkg/errors"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// GolferHandler handles golfer-related HTTP requests
+type GolferHandler struct {
+	golferService *services.GolferService
+}
+
+// NewGolferHandler creates a new golfer handler
+func NewGolferHandler(golferService *services.GolferService) *GolferHandler {
+	return &GolferHandler{
+		golferService: golferService,
+	}
+}
+
+// CreateGolfer handles POST /golfers
+func (h *GolferHandler) CreateGolfer(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateGolferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiErr := errors.New(errors.ErrValidation, "Invalid JSON in request body")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	golfer, err := h.golferService.CreateGolfer(&req)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(golfer)
+
+	log.Info().
+		Str("golfer_id", golfer.ID).
+		Str("request_id", middleware.GetRequestID(r.Context())).
+		Msg("Golfer created via API")
+}
+
+// GetGolfer handles GET /golfers/{golferId}
+func (h *GolferHandler) GetGolfer(w http.ResponseWriter, r *http.Request) {
+	golferID := chi.URLParam(r, "golferId")
+
+	golfer, err := h.golferService.GetGolfer(golferID)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(golfer)
+}
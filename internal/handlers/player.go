@@ -16,13 +16,17 @@ import (
 // PlayerHandler handles player-related HTTP requests
 type PlayerHandler struct {
 	playerService    *services.PlayerService
+	scoreService     *services.ScoreService
+	sideBetService   *services.SideBetService
 	websocketService *services.WebSocketService
 }
 
 // NewPlayerHandler creates a new player handler
-func NewPlayerHandler(playerService *services.PlayerService, websocketService *services.WebSocketService) *PlayerHandler {
+func NewPlayerHandler(playerService *services.PlayerService, scoreService *services.ScoreService, sideBetService *services.SideBetService, websocketService *services.WebSocketService) *PlayerHandler {
 	return &PlayerHandler{
 		playerService:    playerService,
+		scoreService:     scoreService,
+		sideBetService:   sideBetService,
 		websocketService: websocketService,
 	}
 }
@@ -111,7 +115,7 @@ func (h *PlayerHandler) UpdatePlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := h.playerService.UpdatePlayer(gameID, playerID, &req)
+	player, err := h.updatePlayer(gameID, playerID, &req)
 	if err != nil {
 		apiErr := errors.FromError(err)
 		apiErr.RequestID = middleware.GetRequestID(r.Context())
@@ -132,6 +136,26 @@ func (h *PlayerHandler) UpdatePlayer(w http.ResponseWriter, r *http.Request) {
 		Msg("Player updated via API")
 }
 
+// updatePlayer applies a player update while holding the game's score lock.
+// A handicap change rescores the player's holes, so the side bets are rebuilt
+// from the rescored holes.
+func (h *PlayerHandler) updatePlayer(gameID, playerID string, req *models.UpdatePlayerRequest) (*models.Player, error) {
+	unlock := h.scoreService.LockScores(gameID)
+	defer unlock()
+
+	player, err := h.playerService.UpdatePlayer(gameID, playerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Handicap != nil {
+		if err := h.sideBetService.RecalculateSideBets(gameID); err != nil {
+			log.Warn().Err(err).Msg("Failed to recalculate side bets for handicap change")
+		}
+	}
+	return player, nil
+}
+
 // RemovePlayer handles DELETE /games/{gameId}/players/{playerId}
 func (h *PlayerHandler) RemovePlayer(w http.ResponseWriter, r *http.Request) {
 	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
@@ -18,14 +18,16 @@ import (
 type ScoreHandler struct {
 	scoreService     *services.ScoreService
 	sideBetService   *services.SideBetService
+	teamService      *services.TeamService
 	websocketService *services.WebSocketService
 }
 
 // NewScoreHandler creates a new score handler
-func NewScoreHandler(scoreService *services.ScoreService, sideBetService *services.SideBetService, websocketService *services.WebSocketService) *ScoreHandler {
+func NewScoreHandler(scoreService *services.ScoreService, sideBetService *services.SideBetService, teamService *services.TeamService, websocketService *services.WebSocketService) *ScoreHandler {
 	return &ScoreHandler{
 		scoreService:     scoreService,
 		sideBetService:   sideBetService,
+		teamService:      teamService,
 		websocketService: websocketService,
 	}
 }
@@ -44,7 +46,9 @@ func (h *ScoreHandler) RecordScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	score, err := h.scoreService.RecordScore(gameID, playerID, &req)
+	score, err := h.saveScore(gameID, playerID, func() (*models.Score, error) {
+		return h.scoreService.RecordScore(gameID, playerID, &req)
+	})
 	if err != nil {
 		apiErr := errors.FromError(err)
 		apiErr.RequestID = middleware.GetRequestID(r.Context())
@@ -52,12 +56,8 @@ func (h *ScoreHandler) RecordScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update side bets
-	sideBetUpdates, err := h.sideBetService.UpdateSideBetsForScore(gameID, playerID, score)
-	if err != nil {
-		log.Warn().Err(err).Msg("Failed to update side bets for score")
-	} else {
-		score.SideBetUpdates = sideBetUpdates
+	if score.SideBetUpdates != nil {
+		h.broadcastSideBetUpdates(gameID, score.SideBetUpdates)
 	}
 
 	// Broadcast score update
@@ -99,7 +99,9 @@ func (h *ScoreHandler) UpdateScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	score, err := h.scoreService.UpdateScore(gameID, playerID, hole, &req)
+	score, err := h.saveScore(gameID, playerID, func() (*models.Score, error) {
+		return h.scoreService.UpdateScore(gameID, playerID, hole, &req)
+	})
 	if err != nil {
 		apiErr := errors.FromError(err)
 		apiErr.RequestID = middleware.GetRequestID(r.Context())
@@ -107,12 +109,8 @@ func (h *ScoreHandler) UpdateScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update side bets
-	sideBetUpdates, err := h.sideBetService.UpdateSideBetsForScore(gameID, playerID, score)
-	if err != nil {
-		log.Warn().Err(err).Msg("Failed to update side bets for score")
-	} else {
-		score.SideBetUpdates = sideBetUpdates
+	if score.SideBetUpdates != nil {
+		h.broadcastSideBetUpdates(gameID, score.SideBetUpdates)
 	}
 
 	// Broadcast score update
@@ -135,6 +133,19 @@ func (h *ScoreHandler) GetGameScorecard(w http.ResponseWriter, r *http.Request)
 	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
 	gameID := authCtx.GameID
 
+	teamView, apiErr := parseTeamView(r)
+	if apiErr != nil {
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+	if teamView {
+		h.writeTeamView(w, r, func() (interface{}, error) {
+			return h.teamService.GetTeamScorecard(gameID)
+		})
+		return
+	}
+
 	scorecard, err := h.scoreService.GetGameScorecard(gameID)
 	if err != nil {
 		apiErr := errors.FromError(err)
@@ -152,6 +163,19 @@ func (h *ScoreHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
 	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
 	gameID := authCtx.GameID
 
+	teamView, apiErr := parseTeamView(r)
+	if apiErr != nil {
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+	if teamView {
+		h.writeTeamView(w, r, func() (interface{}, error) {
+			return h.teamService.GetTeamLeaderboard(gameID)
+		})
+		return
+	}
+
 	leaderboard, err := h.scoreService.GetLeaderboard(gameID)
 	if err != nil {
 		apiErr := errors.FromError(err)
@@ -162,4 +186,113 @@ func (h *ScoreHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(leaderboard)
-}
\ No newline at end of file
+}
+
+// GetProjections handles GET /games/{gameId}/projections
+func (h *ScoreHandler) GetProjections(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+
+	simulations := 0
+	if value := r.URL.Query().Get("simulations"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			apiErr := errors.ValidationError("simulations", value, "must be a valid integer")
+			apiErr.RequestID = middleware.GetRequestID(r.Context())
+			errors.WriteHTTPError(w, apiErr)
+			return
+		}
+		simulations = parsed
+	}
+
+	projections, err := h.scoreService.GetProjections(gameID, simulations)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(projections)
+}
+
+// saveScore saves a score and updates the side bets for it. Leaderboards wait
+// until both are done, so they never show one without the other.
+func (h *ScoreHandler) saveScore(gameID, playerID string, save func() (*models.Score, error)) (*models.Score, error) {
+	unlock := h.scoreService.LockScores(gameID)
+	defer unlock()
+
+	score, err := save()
+	if err != nil {
+		return nil, err
+	}
+
+	sideBetUpdates, err := h.sideBetService.UpdateSideBetsForScore(gameID, playerID, score)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to update side bets for score")
+	} else {
+		score.SideBetUpdates = sideBetUpdates
+	}
+	return score, nil
+}
+
+// parseTeamView reads the ?view= query parameter, which selects between the
+// individual view (the default) and the team view
+func parseTeamView(r *http.Request) (bool, *errors.APIError) {
+	switch view := r.URL.Query().Get("view"); view {
+	case "", "individual":
+		return false, nil
+	case "team":
+		return true, nil
+	default:
+		return false, errors.ValidationErrorWithAllowedValues("view", view, []interface{}{"individual", "team"})
+	}
+}
+
+// writeTeamView writes a team leaderboard or scorecard
+func (h *ScoreHandler) writeTeamView(w http.ResponseWriter, r *http.Request, load func() (interface{}, error)) {
+	view, err := load()
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view)
+}
+
+// broadcastSideBetUpdates pushes refreshed standings for side bets that
+// change live as scores come in
+func (h *ScoreHandler) broadcastSideBetUpdates(gameID string, updates *models.SideBetUpdates) {
+	var live []models.SideBetType
+	if updates.Skins != nil {
+		live = append(live, models.SideBetSkins)
+	}
+	if updates.Nassau != nil {
+		live = append(live, models.SideBetNassau)
+	}
+	if updates.Wolf != nil {
+		live = append(live, models.SideBetWolf)
+	}
+	if updates.Junk != nil {
+		live = append(live, models.SideBetJunk)
+	}
+	if updates.Snake != nil {
+		live = append(live, models.SideBetSnake)
+	}
+	if updates.Vegas != nil {
+		live = append(live, models.SideBetVegas)
+	}
+
+	for _, betType := range live {
+		standings, err := h.sideBetService.GetStandings(gameID, betType)
+		if err != nil {
+			log.Warn().Err(err).Str("bet_type", string(betType)).Msg("Failed to load side bet standings for broadcast")
+			continue
+		}
+		h.websocketService.BroadcastSideBetUpdate(gameID, string(betType), standings)
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golf-gamez/internal/middleware"
+	"golf-gamez/internal/models"
+	"golf-gamez/internal/services"
+	"golf-gamez/pkg/errors"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// SettlementHandler handles settlement-related HTTP requests
+type SettlementHandler struct {
+	settlementService *services.SettlementService
+	websocketService  *services.WebSocketService
+}
+
+// NewSettlementHandler creates a new settlement handler
+func NewSettlementHandler(settlementService *services.SettlementService, websocketService *services.WebSocketService) *SettlementHandler {
+	return &SettlementHandler{
+		settlementService: settlementService,
+		websocketService:  websocketService,
+	}
+}
+
+// GetSettlement handles GET /games/{gameId}/settlement
+func (h *SettlementHandler) GetSettlement(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+
+	settlement, err := h.settlementService.GetSettlement(gameID)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settlement)
+}
+
+// UpdateTransfer handles PUT /games/{gameId}/settlement/transfers/{transferId}
+func (h *SettlementHandler) UpdateTransfer(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+	transferID := chi.URLParam(r, "transferId")
+
+	var req models.UpdateTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiErr := errors.New(errors.ErrValidation, "Invalid JSON in request body")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	settlement, err := h.settlementService.UpdateTransfer(gameID, transferID, &req)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	h.websocketService.BroadcastGameUpdate(gameID, "settlement_update", settlement)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settlement)
+
+	log.Info().
+		Str("transfer_id", transferID).
+		Str("game_id", gameID).
+		Bool("paid", *req.Paid).
+		Msg("Settlement transfer updated via API")
+}
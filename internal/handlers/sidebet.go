@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"golf-gamez/internal/middleware"
+	"golf-gamez/internal/models"
 	"golf-gamez/internal/services"
 	"golf-gamez/pkg/errors"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,62 +28,81 @@ func NewSideBetHandler(sideBetService *services.SideBetService, websocketService
 	}
 }
 
-// GetBestNineStandings handles GET /games/{gameId}/side-bets/best-nine
-func (h *SideBetHandler) GetBestNineStandings(w http.ResponseWriter, r *http.Request) {
-	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
-	gameID := authCtx.GameID
-
-	standings, err := h.sideBetService.GetBestNineStandings(gameID)
-	if err != nil {
-		apiErr := errors.FromError(err)
-		apiErr.RequestID = middleware.GetRequestID(r.Context())
-		errors.WriteHTTPError(w, apiErr)
-		return
+// Routes mounts every registered side bet under /games/{gameId}/side-bets.
+// Each side bet gets GET /{bet-type} for standings plus any actions it exposes.
+func (h *SideBetHandler) Routes(r chi.Router) {
+	for _, bet := range h.sideBetService.Registry().All() {
+		path := "/" + string(bet.Type())
+		r.Get(path, h.getStandings(bet.Type()))
+
+		if provider, ok := bet.(services.SideBetActionProvider); ok {
+			for _, action := range provider.Actions() {
+				r.Method(action.Method, path+action.Path, h.handleAction(bet.Type(), action))
+			}
+		}
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(standings)
 }
 
-// GetPuttPuttPokerStatus handles GET /games/{gameId}/side-bets/putt-putt-poker
-func (h *SideBetHandler) GetPuttPuttPokerStatus(w http.ResponseWriter, r *http.Request) {
-	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
-	gameID := authCtx.GameID
-
-	status, err := h.sideBetService.GetPuttPuttPokerStatus(gameID)
-	if err != nil {
-		apiErr := errors.FromError(err)
-		apiErr.RequestID = middleware.GetRequestID(r.Context())
-		errors.WriteHTTPError(w, apiErr)
-		return
+// getStandings handles GET /games/{gameId}/side-bets/{bet-type}
+func (h *SideBetHandler) getStandings(betType models.SideBetType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+		gameID := authCtx.GameID
+
+		standings, err := h.sideBetService.GetStandings(gameID, betType)
+		if err != nil {
+			apiErr := errors.FromError(err)
+			apiErr.RequestID = middleware.GetRequestID(r.Context())
+			errors.WriteHTTPError(w, apiErr)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(standings)
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(status)
 }
 
-// DealPokerCards handles POST /games/{gameId}/side-bets/putt-putt-poker/deal
-func (h *SideBetHandler) DealPokerCards(w http.ResponseWriter, r *http.Request) {
-	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
-	gameID := authCtx.GameID
-
-	result, err := h.sideBetService.DealPokerCards(gameID)
-	if err != nil {
-		apiErr := errors.FromError(err)
-		apiErr.RequestID = middleware.GetRequestID(r.Context())
-		errors.WriteHTTPError(w, apiErr)
-		return
+// handleAction handles a side bet's additional endpoints. Results of
+// state-changing actions are broadcast to the game's WebSocket clients.
+func (h *SideBetHandler) handleAction(betType models.SideBetType, action services.SideBetAction) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+		gameID := authCtx.GameID
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			apiErr := errors.New(errors.ErrValidation, "Failed to read request body")
+			apiErr.RequestID = middleware.GetRequestID(r.Context())
+			errors.WriteHTTPError(w, apiErr)
+			return
+		}
+
+		result, err := action.Handle(gameID, body)
+		if err != nil {
+			apiErr := errors.FromError(err)
+			apiErr.RequestID = middleware.GetRequestID(r.Context())
+			errors.WriteHTTPError(w, apiErr)
+			return
+		}
+
+		status := action.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		if action.Method != http.MethodGet {
+			h.websocketService.BroadcastSideBetUpdate(gameID, string(betType), result)
+
+			log.Info().
+				Str("game_id", gameID).
+				Str("bet_type", string(betType)).
+				Str("action", action.Method+" "+action.Path).
+				Str("request_id", middleware.GetRequestID(r.Context())).
+				Msg("Side bet action handled via API")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(result)
 	}
-
-	// Broadcast poker cards dealt update
-	h.websocketService.BroadcastSideBetUpdate(gameID, "putt_putt_poker", result)
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(result)
-
-	log.Info().
-		Str("game_id", gameID).
-		Str("request_id", middleware.GetRequestID(r.Context())).
-		Msg("Poker cards dealt via API")
-}
\ No newline at end of file
+}
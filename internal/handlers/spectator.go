@@ -15,13 +15,15 @@ import (
 
 // SpectatorHandler handles spectator-related HTTP requests
 type SpectatorHandler struct {
-	gameService *services.GameService
+	gameService  *services.GameService
+	scoreService *services.ScoreService
 }
 
 // NewSpectatorHandler creates a new spectator handler
-func NewSpectatorHandler(gameService *services.GameService) *SpectatorHandler {
+func NewSpectatorHandler(gameService *services.GameService, scoreService *services.ScoreService) *SpectatorHandler {
 	return &SpectatorHandler{
-		gameService: gameService,
+		gameService:  gameService,
+		scoreService: scoreService,
 	}
 }
 
@@ -47,13 +49,18 @@ func (h *SpectatorHandler) SpectateGame(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// TODO: Get leaderboard and spectator count
-	// For now, create a basic spectator view
+	leaderboard, err := h.scoreService.GetLeaderboard(game.ID)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	// TODO: Get spectator count
 	spectatorView := &models.SpectatorView{
-		Game: game,
-		Leaderboard: &models.Leaderboard{
-			Overall: []models.LeaderboardEntry{},
-		},
+		Game:           game,
+		Leaderboard:    leaderboard,
 		LiveUpdates:    true,
 		SpectatorCount: 1, // Placeholder
 	}
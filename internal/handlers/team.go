@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"golf-gamez/internal/middleware"
+	"golf-gamez/internal/models"
+	"golf-gamez/internal/services"
+	"golf-gamez/pkg/errors"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// TeamHandler handles team-related HTTP requests
+type TeamHandler struct {
+	teamService      *services.TeamService
+	websocketService *services.WebSocketService
+}
+
+// NewTeamHandler creates a new team handler
+func NewTeamHandler(teamService *services.TeamService, websocketService *services.WebSocketService) *TeamHandler {
+	return &TeamHandler{
+		teamService:      teamService,
+		websocketService: websocketService,
+	}
+}
+
+// GetTeams handles GET /games/{gameId}/teams
+func (h *TeamHandler) GetTeams(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+
+	teams, err := h.teamService.GetTeams(gameID)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(teams)
+}
+
+// RecordTeamScore handles POST /games/{gameId}/teams/{teamId}/scores
+func (h *TeamHandler) RecordTeamScore(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+	teamID := chi.URLParam(r, "teamId")
+
+	var req models.ScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiErr := errors.New(errors.ErrValidation, "Invalid JSON in request body")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	score, err := h.teamService.RecordTeamScore(gameID, teamID, &req)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	h.websocketService.BroadcastTeamScoreUpdate(gameID, teamID, req.Hole, score)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(score)
+
+	log.Info().
+		Str("team_score_id", score.ID).
+		Str("team_id", teamID).
+		Str("game_id", gameID).
+		Int("hole", req.Hole).
+		Str("request_id", middleware.GetRequestID(r.Context())).
+		Msg("Team score recorded via API")
+}
+
+// UpdateTeamScore handles PUT /games/{gameId}/teams/{teamId}/scores/{hole}
+func (h *TeamHandler) UpdateTeamScore(w http.ResponseWriter, r *http.Request) {
+	authCtx, _ := middleware.GetGameAuthFromContext(r.Context())
+	gameID := authCtx.GameID
+	teamID := chi.URLParam(r, "teamId")
+	holeStr := chi.URLParam(r, "hole")
+
+	hole, err := strconv.Atoi(holeStr)
+	if err != nil {
+		apiErr := errors.ValidationError("hole", holeStr, "must be a valid integer")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	var req models.UpdateScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apiErr := errors.New(errors.ErrValidation, "Invalid JSON in request body")
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	score, err := h.teamService.UpdateTeamScore(gameID, teamID, hole, &req)
+	if err != nil {
+		apiErr := errors.FromError(err)
+		apiErr.RequestID = middleware.GetRequestID(r.Context())
+		errors.WriteHTTPError(w, apiErr)
+		return
+	}
+
+	h.websocketService.BroadcastTeamScoreUpdate(gameID, teamID, hole, score)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(score)
+
+	log.Info().
+		Str("team_score_id", score.ID).
+		Str("team_id", teamID).
+		Str("game_id", gameID).
+		Int("hole", hole).
+		Str("request_id", middleware.GetRequestID(r.Context())).
+		Msg("Team score updated via API")
+}
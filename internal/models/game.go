@@ -19,17 +19,77 @@ const (
 type SideBetType string
 
 const (
-	SideBetBestNine      SideBetType = "best-nine"
-	SideBetPuttPuttPoker SideBetType = "putt-putt-poker"
+	SideBetBestNine        SideBetType = "best-nine"
+	SideBetPuttPuttPoker   SideBetType = "putt-putt-poker"
+	SideBetSkins           SideBetType = "skins"
+	SideBetNassau          SideBetType = "nassau"
+	SideBetWolf            SideBetType = "wolf"
+	SideBetBingoBangoBongo SideBetType = "bingo-bango-bongo"
+	SideBetJunk            SideBetType = "junk"
+	SideBetSnake           SideBetType = "snake"
+	SideBetVegas           SideBetType = "vegas"
 )
 
+// ScoringFormat represents how the overall game is ranked
+type ScoringFormat string
+
+const (
+	ScoringStrokePlay         ScoringFormat = "stroke-play"
+	ScoringStableford         ScoringFormat = "stableford"
+	ScoringModifiedStableford ScoringFormat = "modified-stableford"
+	ScoringMatchPlay          ScoringFormat = "match-play"
+)
+
+// TiebreakPolicy represents how a tie for first place is broken
+type TiebreakPolicy string
+
+const (
+	TiebreakCardPlayoff TiebreakPolicy = "card-playoff" // Compare the last 9, 6, 3 and 1 holes
+	TiebreakShared      TiebreakPolicy = "shared"       // Tied players share the win
+	TiebreakPlayoff     TiebreakPolicy = "playoff"      // Tied players play off and the winner is entered
+)
+
+// MaxScoreRule represents the most a hole counts for in adjusted gross scores
+type MaxScoreRule string
+
+const (
+	MaxScoreNetDoubleBogey MaxScoreRule = "net-double-bogey" // Par plus two plus any handicap strokes
+	MaxScoreDoublePar      MaxScoreRule = "double-par"       // Pick up at twice par
+	MaxScoreNone           MaxScoreRule = "none"             // Holes count in full
+)
+
+// Results that can be decided by a playoff
+const (
+	PlayoffOverall  = "overall"
+	PlayoffNet      = "net"
+	PlayoffBestNine = "best_nine"
+)
+
+// StablefordPointTable represents the points awarded for each hole result
+type StablefordPointTable struct {
+	Albatross   int `json:"albatross"`    // Three under par or better
+	Eagle       int `json:"eagle"`
+	Birdie      int `json:"birdie"`
+	Par         int `json:"par"`
+	Bogey       int `json:"bogey"`
+	DoubleBogey int `json:"double_bogey"` // Double bogey or worse
+}
+
 // Game represents a golf game session
 type Game struct {
 	ID             string       `json:"id" db:"id"`
 	Course         string       `json:"course" db:"course"`
+	Tee            string       `json:"tee" db:"tee"`
 	Status         GameStatus   `json:"status" db:"status"`
 	HandicapEnabled bool        `json:"handicap_enabled" db:"handicap_enabled"`
+	HandicapAllowance int       `json:"handicap_allowance" db:"handicap_allowance"` // Percentage of course handicap received
+	ScoringFormat  ScoringFormat `json:"scoring_format" db:"scoring_format"`
+	StablefordPoints *StablefordPointTable `json:"stableford_points,omitempty"`
+	TeamFormat     *TeamFormat  `json:"team_format,omitempty" db:"team_format"`
+	Tiebreak       TiebreakPolicy `json:"tiebreak" db:"tiebreak"`
+	MaxScore       MaxScoreRule `json:"max_score" db:"max_score"`
 	SideBets       []SideBetType `json:"side_bets"`
+	Stakes         *Stakes      `json:"stakes,omitempty"`
 	ShareLink      string       `json:"share_link"`
 	SpectatorLink  string       `json:"spectator_link"`
 	ShareToken     string       `json:"-" db:"share_token"`
@@ -46,30 +106,76 @@ type Game struct {
 // CreateGameRequest represents the request to create a new game
 type CreateGameRequest struct {
 	Course          string        `json:"course" validate:"required,oneof=diamond-run"`
+	Tee             string        `json:"tee,omitempty"` // Players' default tee, the white tees when omitted
 	SideBets        []SideBetType `json:"side_bets,omitempty"`
 	HandicapEnabled bool          `json:"handicap_enabled"`
+	HandicapAllowance *int        `json:"handicap_allowance,omitempty" validate:"omitempty,min=1,max=100"` // Defaults to the format's WHS allowance
+	ScoringFormat   ScoringFormat `json:"scoring_format,omitempty" validate:"omitempty,oneof=stroke-play stableford modified-stableford match-play"`
+	StablefordPoints *StablefordPointTable `json:"stableford_points,omitempty"` // Modified Stableford only
+	TeamFormat      *TeamFormat   `json:"team_format,omitempty" validate:"omitempty,oneof=best-ball scramble shamble"`
+	Tiebreak        TiebreakPolicy `json:"tiebreak,omitempty" validate:"omitempty,oneof=card-playoff shared playoff"`
+	MaxScore        MaxScoreRule  `json:"max_score,omitempty" validate:"omitempty,oneof=net-double-bogey double-par none"`
+	Stakes          *Stakes       `json:"stakes,omitempty"`
 }
 
 // FinalResults represents the final game results
 type FinalResults struct {
 	OverallWinner       *Winner `json:"overall_winner,omitempty"`
+	NetWinner           *Winner `json:"net_winner,omitempty"` // Stroke play with handicaps
 	BestNineWinner      *Winner `json:"best_nine_winner,omitempty"`
 	PuttPuttPokerWinner *Winner `json:"putt_putt_poker_winner,omitempty"`
+	Skins               []SkinsResult `json:"skins,omitempty"`
+	Nassau              *NassauSettlement `json:"nassau,omitempty"`
+	Wolf                []WolfResult      `json:"wolf,omitempty"`
+	BingoBangoBongo     []BingoBangoBongoResult `json:"bingo_bango_bongo,omitempty"`
+	Junk                []JunkResult      `json:"junk,omitempty"`
+	Snake               *SnakeSettlement  `json:"snake,omitempty"`
+	Vegas               *VegasSettlement  `json:"vegas,omitempty"`
+	Match               *MatchPlayStatus  `json:"match,omitempty"`
+	Playoffs            []Playoff         `json:"playoffs,omitempty"`
 }
 
 // Winner represents a game winner
 type Winner struct {
 	PlayerID string `json:"player_id"`
 	Score    string `json:"score"`
+	PartnerIDs []string `json:"partner_ids,omitempty"` // For four-ball and team formats
+	TiedWith []string `json:"tied_with,omitempty"` // Players sharing the win
+	Tiebreak string `json:"tiebreak,omitempty"`  // How a tie for first was broken, e.g. "last 6"
 	Hand     string `json:"hand,omitempty"`     // For poker
 	Cards    []string `json:"cards,omitempty"`  // For poker
 }
 
+// Playoff represents a tie for first place settled by a sudden-death playoff
+type Playoff struct {
+	Result    string   `json:"result"` // overall, net or best_nine
+	Score     string   `json:"score"`
+	PlayerIDs []string `json:"player_ids"`
+	WinnerID  *string  `json:"winner_id,omitempty"` // Entered once the playoff is decided
+}
+
+// PlayoffRequest represents the request to enter a playoff's winner
+type PlayoffRequest struct {
+	WinnerID string `json:"winner_id" validate:"required"`
+}
+
 // CourseInfo represents golf course information
 type CourseInfo struct {
-	Name     string     `json:"name"`
-	Holes    []HoleInfo `json:"holes"`
-	TotalPar int        `json:"total_par"`
+	Name        string     `json:"name"`
+	Holes       []HoleInfo `json:"holes"`                  // Men's par and stroke index
+	FemaleHoles []HoleInfo `json:"female_holes,omitempty"` // Women's par and stroke index
+	TotalPar    int        `json:"total_par"`
+	Tees        []TeeSet   `json:"tees"`
+}
+
+// TeeSet represents the ratings of a course played from one set of tees,
+// which are rated separately for men and women
+type TeeSet struct {
+	Name         string  `json:"name"`
+	Gender       Gender  `json:"gender"` // male or female
+	CourseRating float64 `json:"course_rating"`
+	SlopeRating  int     `json:"slope_rating"`
+	Par          int     `json:"par"`
 }
 
 // HoleInfo represents information about a specific hole
@@ -87,6 +193,7 @@ type GameCompletionResult struct {
 	Status       GameStatus    `json:"status"`
 	CompletedAt  time.Time     `json:"completed_at"`
 	FinalResults *FinalResults `json:"final_results"`
+	Settlement   *Settlement   `json:"settlement"`
 }
 
 // SpectatorView represents the spectator view of a game
@@ -97,6 +204,41 @@ type SpectatorView struct {
 	SpectatorCount int          `json:"spectator_count"`
 }
 
+// DefaultStablefordPoints returns the standard point table for a Stableford format
+func DefaultStablefordPoints(format ScoringFormat) *StablefordPointTable {
+	switch format {
+	case ScoringStableford:
+		return &StablefordPointTable{Albatross: 5, Eagle: 4, Birdie: 3, Par: 2, Bogey: 1, DoubleBogey: 0}
+	case ScoringModifiedStableford:
+		return &StablefordPointTable{Albatross: 8, Eagle: 5, Birdie: 2, Par: 0, Bogey: -1, DoubleBogey: -3}
+	default:
+		return nil
+	}
+}
+
+// IsStableford reports whether the format ranks players by Stableford points
+func (f ScoringFormat) IsStableford() bool {
+	return f == ScoringStableford || f == ScoringModifiedStableford
+}
+
+// Points returns the Stableford points for a hole played in strokes
+func (t *StablefordPointTable) Points(strokes, par int) int {
+	switch diff := strokes - par; {
+	case diff <= -3:
+		return t.Albatross
+	case diff == -2:
+		return t.Eagle
+	case diff == -1:
+		return t.Birdie
+	case diff == 0:
+		return t.Par
+	case diff == 1:
+		return t.Bogey
+	default:
+		return t.DoubleBogey
+	}
+}
+
 // MarshalSideBets converts side bets slice to JSON string for database storage
 func (g *Game) MarshalSideBets() (string, error) {
 	if len(g.SideBets) == 0 {
@@ -115,6 +257,44 @@ func (g *Game) UnmarshalSideBets(data string) error {
 	return json.Unmarshal([]byte(data), &g.SideBets)
 }
 
+// MarshalStablefordPoints converts the point table to JSON string for database storage
+func (g *Game) MarshalStablefordPoints() (string, error) {
+	if g.StablefordPoints == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(g.StablefordPoints)
+	return string(data), err
+}
+
+// UnmarshalStablefordPoints converts JSON string from database to the point table
+func (g *Game) UnmarshalStablefordPoints(data string) error {
+	if data == "" {
+		g.StablefordPoints = nil
+		return nil
+	}
+	g.StablefordPoints = &StablefordPointTable{}
+	return json.Unmarshal([]byte(data), g.StablefordPoints)
+}
+
+// MarshalStakes converts the stakes to JSON string for database storage
+func (g *Game) MarshalStakes() (string, error) {
+	if g.Stakes == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(g.Stakes)
+	return string(data), err
+}
+
+// UnmarshalStakes converts JSON string from database to the stakes
+func (g *Game) UnmarshalStakes(data string) error {
+	if data == "" {
+		g.Stakes = nil
+		return nil
+	}
+	g.Stakes = &Stakes{}
+	return json.Unmarshal([]byte(data), g.Stakes)
+}
+
 // MarshalFinalResults converts final results to JSON string for database storage
 func (g *Game) MarshalFinalResults() (string, error) {
 	if g.FinalResults == nil {
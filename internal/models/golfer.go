@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Golfer represents a golfer whose handicap index is tracked across games
+type Golfer struct {
+	ID            string                `json:"id"`
+	Name          string                `json:"name"`
+	HandicapIndex *float64              `json:"handicap_index"`     // Nil until a starting index is given or 3 rounds are posted
+	LowIndex      *float64              `json:"low_handicap_index"` // Lowest index of the last 365 days, used by the caps
+	Rounds        []HandicapRound       `json:"rounds"`             // Most recent 20, newest first
+	History       []HandicapIndexChange `json:"history"`            // Newest first
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+// HandicapRound represents a completed round posted to a golfer's record
+type HandicapRound struct {
+	GameID        string    `json:"game_id"`
+	PlayedAt      time.Time `json:"played_at"`
+	AdjustedGross int       `json:"adjusted_gross"` // Capped at net double bogey
+	CourseRating  float64   `json:"course_rating"`
+	SlopeRating   int       `json:"slope_rating"`
+	Differential  float64   `json:"differential"`
+	Counted       bool      `json:"counted"` // Among the differentials the current index uses
+}
+
+// HandicapIndexChange represents a golfer's handicap index after a revision
+type HandicapIndexChange struct {
+	HandicapIndex float64   `json:"handicap_index"`
+	LowIndex      *float64  `json:"low_handicap_index,omitempty"`
+	GameID        *string   `json:"game_id,omitempty"` // Nil for the starting index
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CreateGolferRequest represents the request to start tracking a golfer
+type CreateGolferRequest struct {
+	Name          string   `json:"name" validate:"required,min=1,max=100"`
+	HandicapIndex *float64 `json:"handicap_index,omitempty" validate:"omitempty,min=-10,max=54"` // Starting index until 3 rounds are posted
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -18,9 +19,15 @@ type Player struct {
 	ID        string       `json:"id" db:"id"`
 	GameID    string       `json:"game_id" db:"game_id"`
 	Name      string       `json:"name" db:"name"`
-	Handicap  float64      `json:"handicap" db:"handicap"`
+	Handicap  float64      `json:"handicap" db:"handicap"` // Handicap index
+	CourseHandicap  *int   `json:"course_handicap,omitempty"`  // Handicap games only
+	PlayingHandicap *int   `json:"playing_handicap,omitempty"` // Strokes received in the game
 	Gender    *Gender      `json:"gender,omitempty" db:"gender"`
+	GolferID  *string      `json:"golfer_id,omitempty" db:"golfer_id"` // Tracked golfer the round posts to
+	Tee       string       `json:"tee" db:"tee"` // Rated for the player's gender
 	Position  int          `json:"position" db:"position"`
+	TeamID    *string      `json:"team_id,omitempty" db:"team_id"`
+	SideBets  []SideBetType `json:"side_bets" db:"side_bets"` // Side bets the player takes part in
 	CreatedAt time.Time    `json:"created_at" db:"created_at"`
 	Stats     *PlayerStats `json:"stats,omitempty"`
 }
@@ -28,30 +35,69 @@ type Player struct {
 // CreatePlayerRequest represents the request to add a player to a game
 type CreatePlayerRequest struct {
 	Name     string  `json:"name" validate:"required,min=1,max=100"`
-	Handicap float64 `json:"handicap" validate:"required,min=0,max=54"`
+	Handicap *float64 `json:"handicap,omitempty" validate:"omitempty,min=-10,max=54"` // Defaults to the golfer's handicap index
+	GolferID *string  `json:"golfer_id,omitempty"`
+	Tee      *string `json:"tee,omitempty"` // Defaults to the game's tee
 	Gender   *Gender `json:"gender,omitempty" validate:"omitempty,oneof=male female other"`
+	Team     *string `json:"team,omitempty" validate:"omitempty,min=1,max=50"` // Team name, created on first use
+	SideBets *[]SideBetType `json:"side_bets,omitempty"` // Side bets to join, every side bet in the game when omitted
 }
 
 // UpdatePlayerRequest represents the request to update a player
 type UpdatePlayerRequest struct {
 	Name     *string  `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
-	Handicap *float64 `json:"handicap,omitempty" validate:"omitempty,min=0,max=54"`
+	Handicap *float64 `json:"handicap,omitempty" validate:"omitempty,min=-10,max=54"`
+	Tee      *string  `json:"tee,omitempty"`        // Before the game starts only
+	SideBets *[]SideBetType `json:"side_bets,omitempty"` // Before the game starts only
+}
+
+// PlaysSideBet reports whether the player takes part in a side bet
+func (p *Player) PlaysSideBet(sideBet SideBetType) bool {
+	for _, playing := range p.SideBets {
+		if playing == sideBet {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalSideBets converts the player's side bets to JSON string for database storage
+func (p *Player) MarshalSideBets() (string, error) {
+	if len(p.SideBets) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal(p.SideBets)
+	return string(data), err
+}
+
+// UnmarshalSideBets converts JSON string from database to the player's side bets
+func (p *Player) UnmarshalSideBets(data string) error {
+	if data == "" || data == "[]" {
+		p.SideBets = []SideBetType{}
+		return nil
+	}
+	return json.Unmarshal([]byte(data), &p.SideBets)
 }
 
 // PlayerStats represents player statistics during a game
 type PlayerStats struct {
-	HolesCompleted int    `json:"holes_completed"`
-	CurrentScore   string `json:"current_score"`
-	TotalPutts     int    `json:"total_putts"`
-	PokerCards     *int   `json:"poker_cards,omitempty"`
-	BestNineScore  string `json:"best_nine_score,omitempty"`
+	HolesCompleted  int    `json:"holes_completed"`
+	CurrentScore    string `json:"current_score"`
+	TotalPutts      int    `json:"total_putts"`
+	TotalStrokes    int    `json:"total_strokes"`
+	AdjustedStrokes int    `json:"adjusted_strokes"` // Adjusted gross score, each hole capped by the game's max score rule
+	Points          *int   `json:"points,omitempty"` // Stableford formats only
+	PokerCards      *int   `json:"poker_cards,omitempty"`
+	BestNineScore   string `json:"best_nine_score,omitempty"`
 }
 
 // PlayerSummary represents a condensed player view
 type PlayerSummary struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	Handicap *float64 `json:"handicap,omitempty"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Handicap        *float64 `json:"handicap,omitempty"`
+	CourseHandicap  *int     `json:"course_handicap,omitempty"`
+	PlayingHandicap *int     `json:"playing_handicap,omitempty"`
 }
 
 // PlayerDetail represents detailed player information including scores
@@ -0,0 +1,40 @@
+package models
+
+// Projection statuses
+const (
+	ProjectionInContention = "in_contention"
+	ProjectionOutOfReach   = "out_of_reach" // Can't tie even with the best possible scores
+	ProjectionFinished     = "finished"     // No holes left to play
+)
+
+// Projections represents what each player needs over their remaining holes
+type Projections struct {
+	GameID      string       `json:"game_id"`
+	Simulations int          `json:"simulations"` // Zero when win probabilities weren't requested
+	Results     []Projection `json:"results"`
+}
+
+// Projection represents the targets for one result: the main round or a side bet
+type Projection struct {
+	Result  string             `json:"result"` // overall, net or a side bet type
+	Players []PlayerProjection `json:"players"`
+}
+
+// PlayerProjection represents what one player needs to win or tie a result
+type PlayerProjection struct {
+	Player         PlayerSummary     `json:"player"`
+	Current        string            `json:"current"` // e.g. "+3", "24 pts" or "2 skins"
+	HolesRemaining int               `json:"holes_remaining"`
+	Status         string            `json:"status"`
+	ToWin          *ProjectionTarget `json:"to_win,omitempty"`
+	ToTie          *ProjectionTarget `json:"to_tie,omitempty"`
+	WinProbability *float64          `json:"win_probability,omitempty"` // Share of simulated rounds won
+}
+
+// ProjectionTarget represents the most a player can take over their remaining
+// holes and still reach a result
+type ProjectionTarget struct {
+	Strokes    int    `json:"strokes"`      // Total over the remaining holes
+	ScoreToPar string `json:"score_to_par"` // The same total relative to the par of those holes
+	AnyScore   bool   `json:"any_score"`    // Reached however many strokes the player takes
+}
@@ -12,11 +12,13 @@ type Score struct {
 	GameID          string           `json:"game_id" db:"game_id"`
 	Hole            int              `json:"hole" db:"hole"`
 	Strokes         int              `json:"strokes" db:"strokes"`
+	AdjustedStrokes int              `json:"adjusted_strokes" db:"adjusted_strokes"` // Capped by the game's max score rule
 	Putts           int              `json:"putts" db:"putts"`
 	Par             int              `json:"par" db:"par"`
 	ScoreToPar      string           `json:"score_to_par"`
-	HandicapStroke  bool             `json:"handicap_stroke" db:"handicap_stroke"`
+	HandicapStrokes int              `json:"handicap_strokes" db:"handicap_strokes"` // Negative when a plus handicap gives strokes back
 	EffectiveScore  int              `json:"effective_score" db:"effective_score"`
+	Points          *int             `json:"points,omitempty"` // Stableford points on the scorecard
 	CreatedAt       time.Time        `json:"created_at" db:"created_at"`
 	UpdatedAt       *time.Time       `json:"updated_at,omitempty" db:"updated_at"`
 	SideBetUpdates  *SideBetUpdates  `json:"side_bet_updates,omitempty"`
@@ -38,6 +40,12 @@ type UpdateScoreRequest struct {
 // SideBetUpdates represents side bet updates when a score is recorded
 type SideBetUpdates struct {
 	PuttPuttPoker *PuttPuttPokerUpdate `json:"putt_putt_poker,omitempty"`
+	Skins         *SkinsUpdate         `json:"skins,omitempty"`
+	Nassau        *NassauUpdate        `json:"nassau,omitempty"`
+	Wolf          *WolfHoleResult      `json:"wolf,omitempty"`
+	Junk          []JunkEvent          `json:"junk,omitempty"`
+	Snake         *SnakeUpdate         `json:"snake,omitempty"`
+	Vegas         *VegasHoleResult     `json:"vegas,omitempty"`
 }
 
 // PuttPuttPokerUpdate represents poker updates for a score
@@ -47,6 +55,24 @@ type PuttPuttPokerUpdate struct {
 	TotalCards     int  `json:"total_cards"`
 }
 
+// SkinsUpdate represents the skins outcome of the scored hole
+type SkinsUpdate struct {
+	Hole       int     `json:"hole"`
+	Status     string  `json:"status"` // pending, won or carried
+	WinnerID   *string `json:"winner_id,omitempty"`
+	SkinsValue int     `json:"skins_value"`
+	CarriedPot int     `json:"carried_pot"`
+}
+
+// NassauUpdate represents the Nassau match statuses after a score
+type NassauUpdate struct {
+	Thru     int      `json:"thru"`
+	Front    string   `json:"front"`
+	Back     string   `json:"back"`
+	Overall  string   `json:"overall"`
+	NewPress []string `json:"new_presses,omitempty"` // Bet IDs of presses started by this score
+}
+
 // GameScorecard represents the complete scorecard for all players
 type GameScorecard struct {
 	Game       GameSummary        `json:"game"`
@@ -66,13 +92,17 @@ type ScorecardPlayer struct {
 	ID       string       `json:"id"`
 	Name     string       `json:"name"`
 	Position int          `json:"position"`
+	Tee      TeeSet       `json:"tee"`   // The player's tee, rated for their gender
+	Holes    []HoleInfo   `json:"holes"` // The par and stroke index the player plays to
 	Scores   []Score      `json:"scores"`
 	Totals   *PlayerStats `json:"totals"`
 }
 
 // Leaderboard represents current game standings
 type Leaderboard struct {
+	ScoringFormat ScoringFormat  `json:"scoring_format,omitempty"`
 	Overall   []LeaderboardEntry `json:"overall"`
+	Match     *MatchPlayStatus    `json:"match,omitempty"` // Match play only
 	SideBets  *SideBetLeaderboard `json:"side_bets,omitempty"`
 }
 
@@ -81,15 +111,76 @@ type LeaderboardEntry struct {
 	Position       int           `json:"position"`
 	Player         PlayerSummary `json:"player"`
 	Score          string        `json:"score"`
+	Points         *int          `json:"points,omitempty"` // Stableford formats only
 	HolesCompleted int           `json:"holes_completed"`
 	TotalPutts     int           `json:"total_putts"`
 	Trend          *string       `json:"trend,omitempty"`
 }
 
-// SideBetLeaderboard represents side bet standings
+// Match play formats
+const (
+	MatchSingles  = "singles"
+	MatchFourBall = "four-ball"
+)
+
+// MatchPlayStatus represents the state of a match play game
+type MatchPlayStatus struct {
+	Format    string            `json:"format"` // singles or four-ball
+	Sides     []MatchSide       `json:"sides"`
+	Strokes   map[string]int    `json:"strokes,omitempty"` // Handicap strokes received by player ID
+	Thru      int               `json:"thru"`
+	Status    string            `json:"status"` // e.g. "2 UP", "AS" or "3&2"
+	Leader    *string           `json:"leader,omitempty"` // Side leading or winning the match
+	Dormie    bool              `json:"dormie"`
+	Complete  bool              `json:"complete"`
+	ClosedOut bool              `json:"closed_out"` // Won before the last hole
+	Holes     []MatchHoleResult `json:"holes"`
+}
+
+// MatchSide represents one side of a match
+type MatchSide struct {
+	Side    string          `json:"side"` // a or b
+	Name    string          `json:"name"`
+	Players []PlayerSummary `json:"players"`
+	Status  string          `json:"status"` // From this side's view, e.g. "2 UP", "2 DN" or "Won 3&2"
+}
+
+// MatchHoleResult represents the outcome of a single match play hole
+type MatchHoleResult struct {
+	Hole   int    `json:"hole"`
+	SideA  int    `json:"side_a"` // Best net score
+	SideB  int    `json:"side_b"`
+	Winner string  `json:"winner"` // a, b or halved
+	Status string  `json:"status"` // Match status after the hole
+	Leader *string `json:"leader,omitempty"`
+}
+
+// SideBetLeaderboard represents the standings of each side bet enabled for a game
 type SideBetLeaderboard struct {
-	BestNine      []BestNineResult      `json:"best_nine,omitempty"`
-	PuttPuttPoker []PuttPuttPokerResult `json:"putt_putt_poker,omitempty"`
+	BestNine        []BestNineResult          `json:"best_nine,omitempty"`
+	PuttPuttPoker   []PuttPuttPokerResult     `json:"putt_putt_poker,omitempty"`
+	Skins           *SkinsStandings           `json:"skins,omitempty"`
+	Nassau          *NassauStandings          `json:"nassau,omitempty"`
+	Wolf            *WolfStandings            `json:"wolf,omitempty"`
+	BingoBangoBongo *BingoBangoBongoStandings `json:"bingo_bango_bongo,omitempty"`
+	Junk            *JunkStandings            `json:"junk,omitempty"`
+	Snake           *SnakeStandings           `json:"snake,omitempty"`
+	Vegas           *VegasStandings           `json:"vegas,omitempty"`
+	Bets            []SideBetStatus           `json:"bets"` // One per side bet enabled for the game
+}
+
+// Side bet leaderboard statuses
+const (
+	SideBetAvailable   = "available"
+	SideBetUnavailable = "unavailable"
+)
+
+// SideBetStatus reports whether a side bet's standings are on the leaderboard
+type SideBetStatus struct {
+	BetType SideBetType `json:"bet_type"`
+	Status  string      `json:"status"`            // available, or unavailable when the bet can't be scored yet
+	Code    string      `json:"code,omitempty"`    // Why the bet is unavailable, e.g. invalid_game_state
+	Message string      `json:"message,omitempty"`
 }
 
 // FormatScoreToPar formats a score relative to par
@@ -106,11 +197,8 @@ func FormatScoreToPar(strokes, par int) string {
 }
 
 // CalculateEffectiveScore calculates score after handicap adjustment
-func CalculateEffectiveScore(strokes, par int, handicapStroke bool) int {
-	if handicapStroke {
-		return strokes - 1 - par
-	}
-	return strokes - par
+func CalculateEffectiveScore(strokes, par, handicapStrokes int) int {
+	return strokes - handicapStrokes - par
 }
 
 // Helper functions
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// SettlementAmount represents a player's net dollars from a side bet
+type SettlementAmount struct {
+	Player PlayerSummary `json:"player"`
+	Amount float64       `json:"amount"` // Positive when the player collects
+}
+
+// SettlementBet represents one side bet's part of a settlement
+type SettlementBet struct {
+	BetType SideBetType        `json:"bet_type"`
+	Pending bool               `json:"pending"` // The bet can't be settled yet, e.g. poker hands not dealt
+	Amounts []SettlementAmount `json:"amounts"`
+}
+
+// SettlementBalance represents a player's overall position in a settlement
+type SettlementBalance struct {
+	Player      PlayerSummary `json:"player"`
+	Net         float64       `json:"net"`         // Total across every settled bet
+	Outstanding float64       `json:"outstanding"` // Net less transfers already paid
+}
+
+// SettlementTransfer represents a payment from one player to another
+type SettlementTransfer struct {
+	ID     string        `json:"id"`
+	From   PlayerSummary `json:"from"`
+	To     PlayerSummary `json:"to"`
+	Amount float64       `json:"amount"`
+	Paid   bool          `json:"paid"`
+	PaidAt *time.Time    `json:"paid_at,omitempty"`
+}
+
+// Settlement represents who owes whom once a game is completed
+type Settlement struct {
+	GameID     string               `json:"game_id"`
+	Settled    bool                 `json:"settled"`     // Every bet is settled and every transfer paid
+	PointsOnly bool                 `json:"points_only"` // The game isn't played for money
+	Bets       []SettlementBet      `json:"bets"`
+	Balances   []SettlementBalance  `json:"balances"`
+	Transfers  []SettlementTransfer `json:"transfers"`
+}
+
+// UpdateTransferRequest represents the request to mark a transfer paid or unpaid
+type UpdateTransferRequest struct {
+	Paid *bool `json:"paid" validate:"required"`
+}
@@ -15,6 +15,7 @@ type BestNineResult struct {
 	RawBestNine        string        `json:"raw_best_nine"`
 	HandicapAdjustment string        `json:"handicap_adjustment"`
 	FinalScore         string        `json:"final_score"`
+	ProjectedFinal     *string       `json:"projected_final,omitempty"` // For rounds still in progress
 	Position           int           `json:"position"`
 }
 
@@ -52,6 +53,7 @@ type PuttPuttPokerStatus struct {
 	CurrentHole *int                  `json:"current_hole"`
 	Players     []PuttPuttPokerResult `json:"players"`
 	PotInfo     *PotInfo              `json:"pot_info,omitempty"`
+	SeedHash    string                `json:"seed_hash,omitempty"` // SHA-256 commitment to the deal seed
 }
 
 // PuttingStats represents putting performance statistics
@@ -67,6 +69,7 @@ type PotInfo struct {
 	BaseBet          float64 `json:"base_bet"`
 	PenaltyAdditions float64 `json:"penalty_additions"`
 	TotalPot         float64 `json:"total_pot"`
+	PayoutSplit      []int   `json:"payout_split,omitempty"` // Percent of the pot for each finishing place
 }
 
 // PokerHandType represents poker hand types
@@ -106,6 +109,7 @@ type BestPokerHand struct {
 type PokerDealResult struct {
 	DealTimestamp   time.Time        `json:"deal_timestamp"`
 	RandomSeed      string           `json:"random_seed"`
+	SeedHash        string           `json:"seed_hash"`
 	Players         []PokerHand      `json:"players"`
 	Winner          *PokerWinner     `json:"winner,omitempty"`
 	PotDistribution *PotDistribution `json:"pot_distribution,omitempty"`
@@ -116,6 +120,7 @@ type PokerWinner struct {
 	PlayerID     string   `json:"player_id"`
 	HandType     string   `json:"hand_type"`
 	WinningCards []string `json:"winning_cards"`
+	TiedWith     []string `json:"tied_with,omitempty"` // Player IDs sharing the pot
 }
 
 // PotDistribution represents how the pot is distributed
@@ -123,6 +128,14 @@ type PotDistribution struct {
 	TotalPot   float64         `json:"total_pot"`
 	WinnerTake float64         `json:"winner_take"`
 	Breakdown  *PotBreakdown   `json:"breakdown,omitempty"`
+	Payouts    []PotPayout     `json:"payouts,omitempty"`
+}
+
+// PotPayout represents a player's share of the pot
+type PotPayout struct {
+	Player   PlayerSummary `json:"player"`
+	Position int           `json:"position"`
+	Amount   float64       `json:"amount"`
 }
 
 // PotBreakdown shows pot composition
@@ -144,6 +157,400 @@ type PuttPuttPokerCard struct {
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
+// SkinsHoleResult represents the skins outcome of a single hole
+type SkinsHoleResult struct {
+	Hole       int            `json:"hole"`
+	Status     string         `json:"status"` // pending, won or carried
+	Winner     *PlayerSummary `json:"winner,omitempty"`
+	Score      *int           `json:"score,omitempty"` // Winning strokes, net when handicaps apply
+	SkinsValue int            `json:"skins_value"`     // This hole's skin plus any carried in
+	PotValue   float64        `json:"pot_value"`
+}
+
+// SkinsResult represents a player's skins total
+type SkinsResult struct {
+	Player   PlayerSummary `json:"player"`
+	Skins    int           `json:"skins"`
+	HolesWon []int         `json:"holes_won"`
+	Winnings float64       `json:"winnings"`
+	Position int           `json:"position"`
+}
+
+// SkinsStandings represents the skins standings for a game
+type SkinsStandings struct {
+	BetType         SideBetType       `json:"bet_type"`
+	Status          GameStatus        `json:"status"`
+	HandicapEnabled bool              `json:"handicap_enabled"`
+	SkinValue       float64           `json:"skin_value"`
+	Holes           []SkinsHoleResult `json:"holes"`
+	Players         []SkinsResult     `json:"players"`
+	CarriedPot      int               `json:"carried_pot"` // Skins riding on the next decided hole
+	CarriedValue    float64           `json:"carried_value"`
+}
+
+// SkinsCalculationData represents stored calculation data for skins
+type SkinsCalculationData struct {
+	Skins    int     `json:"skins"`
+	HolesWon []int   `json:"holes_won"`
+	Winnings float64 `json:"winnings"`
+}
+
+// Nassau segments
+const (
+	NassauFront   = "front"
+	NassauBack    = "back"
+	NassauOverall = "overall"
+)
+
+// NassauSide represents one side of a Nassau match, a player or a two-player team
+type NassauSide struct {
+	Side    string          `json:"side"` // a or b
+	Name    string          `json:"name"`
+	Players []PlayerSummary `json:"players"`
+}
+
+// NassauMatch represents a single bet within a Nassau: one of the three
+// matches or a press
+type NassauMatch struct {
+	ID          string  `json:"id"`
+	Segment     string  `json:"segment"`
+	Kind        string  `json:"kind"` // match, auto_press or manual_press
+	StartHole   int     `json:"start_hole"`
+	EndHole     int     `json:"end_hole"`
+	HolesPlayed int     `json:"holes_played"`
+	Leader      *string `json:"leader,omitempty"` // Leading side
+	Margin      int     `json:"margin"`
+	Status      string  `json:"status"` // e.g. "1 up thru 7"
+	Complete    bool    `json:"complete"`
+	Winner      *string `json:"winner,omitempty"` // Winning side once complete, omitted when halved
+	Stake       float64 `json:"stake"`
+}
+
+// NassauHoleResult represents a hole's result between the two sides
+type NassauHoleResult struct {
+	Hole   int    `json:"hole"`
+	SideA  int    `json:"side_a"` // Best ball net strokes
+	SideB  int    `json:"side_b"`
+	Winner string `json:"winner"` // a, b or halved
+}
+
+// NassauPayout represents a player's net Nassau result in dollars
+type NassauPayout struct {
+	Player PlayerSummary `json:"player"`
+	Amount float64       `json:"amount"`
+}
+
+// NassauStandings represents the Nassau matches for a game
+type NassauStandings struct {
+	BetType         SideBetType        `json:"bet_type"`
+	Status          GameStatus         `json:"status"`
+	HandicapEnabled bool               `json:"handicap_enabled"`
+	Stake           float64            `json:"stake"`
+	AutoPress       bool               `json:"auto_press"`
+	Sides           []NassauSide       `json:"sides"`
+	Strokes         map[string]int     `json:"strokes,omitempty"` // Handicap strokes received by player ID
+	Thru            int                `json:"thru"`
+	Bets            []NassauMatch      `json:"bets"`
+	Holes           []NassauHoleResult `json:"holes"`
+	Settlement      []NassauPayout     `json:"settlement"`
+}
+
+// NassauSettlement represents the final Nassau results
+type NassauSettlement struct {
+	Bets       []NassauMatch  `json:"bets"`
+	Settlement []NassauPayout `json:"settlement"`
+}
+
+// NassauSetupRequest configures the sides and stakes of a Nassau
+type NassauSetupRequest struct {
+	Sides     [][]string `json:"sides"` // Two sides of one or two player IDs each
+	Stake     *float64   `json:"stake,omitempty"`
+	AutoPress *bool      `json:"auto_press,omitempty"`
+}
+
+// NassauPressRequest requests a manual press
+type NassauPressRequest struct {
+	Segment  string `json:"segment"`   // front, back or overall
+	PlayerID string `json:"player_id"` // Player on the side calling the press
+}
+
+// NassauCalculationData represents stored calculation data for Nassau
+type NassauCalculationData struct {
+	Side   string  `json:"side"`
+	Amount float64 `json:"amount"`
+}
+
+// Wolf choices
+const (
+	WolfPartner = "partner"
+	WolfLone    = "lone"
+	WolfBlind   = "blind"
+)
+
+// WolfChoiceRequest records the wolf's choice for a hole
+type WolfChoiceRequest struct {
+	Hole      int     `json:"hole"`
+	Choice    string  `json:"choice"`               // partner, lone or blind
+	PartnerID *string `json:"partner_id,omitempty"` // Required when choosing a partner
+}
+
+// WolfHoleResult represents the wolf's choice and outcome on a hole
+type WolfHoleResult struct {
+	Hole          int            `json:"hole"`
+	Wolf          PlayerSummary  `json:"wolf"`
+	Choice        *string        `json:"choice,omitempty"` // Omitted until chosen
+	Partner       *PlayerSummary `json:"partner,omitempty"`
+	WolfScore     *int           `json:"wolf_score,omitempty"`     // Best ball of the wolf's side
+	OpponentScore *int           `json:"opponent_score,omitempty"` // Best ball of the other side
+	Result        string         `json:"result"`                   // pending, won, lost or halved
+	Points        map[string]int `json:"points,omitempty"`         // Points earned by player ID
+}
+
+// WolfResult represents a player's wolf points
+type WolfResult struct {
+	Player   PlayerSummary `json:"player"`
+	Points   int           `json:"points"`
+	Position int           `json:"position"`
+}
+
+// WolfStandings represents the wolf standings for a game
+type WolfStandings struct {
+	BetType         SideBetType      `json:"bet_type"`
+	Status          GameStatus       `json:"status"`
+	HandicapEnabled bool             `json:"handicap_enabled"`
+	Players         []WolfResult     `json:"players"`
+	Holes           []WolfHoleResult `json:"holes"`
+	NextHole        *int             `json:"next_hole,omitempty"` // First hole still to be decided
+}
+
+// WolfCalculationData represents stored calculation data for wolf
+type WolfCalculationData struct {
+	Points int `json:"points"`
+}
+
+// BingoBangoBongoEventRequest records a hole's Bingo Bango Bongo awards.
+// Each award names the player who earned it, or is omitted when nobody did.
+type BingoBangoBongoEventRequest struct {
+	Hole  int     `json:"hole"`
+	Bingo *string `json:"bingo,omitempty"` // First on the green
+	Bango *string `json:"bango,omitempty"` // Closest to the pin once all are on
+	Bongo *string `json:"bongo,omitempty"` // First in the hole
+}
+
+// BingoBangoBongoHole represents the awards recorded for a hole
+type BingoBangoBongoHole struct {
+	Hole  int            `json:"hole"`
+	Bingo *PlayerSummary `json:"bingo,omitempty"`
+	Bango *PlayerSummary `json:"bango,omitempty"`
+	Bongo *PlayerSummary `json:"bongo,omitempty"`
+}
+
+// BingoBangoBongoResult represents a player's Bingo Bango Bongo points
+type BingoBangoBongoResult struct {
+	Player   PlayerSummary `json:"player"`
+	Bingos   int           `json:"bingos"`
+	Bangos   int           `json:"bangos"`
+	Bongos   int           `json:"bongos"`
+	Points   int           `json:"points"`
+	Position int           `json:"position"`
+}
+
+// BingoBangoBongoStandings represents the Bingo Bango Bongo standings for a game
+type BingoBangoBongoStandings struct {
+	BetType SideBetType             `json:"bet_type"`
+	Status  GameStatus              `json:"status"`
+	Players []BingoBangoBongoResult `json:"players"`
+	Holes   []BingoBangoBongoHole   `json:"holes"` // Holes with recorded awards
+}
+
+// BingoBangoBongoCalculationData represents stored calculation data for Bingo Bango Bongo
+type BingoBangoBongoCalculationData struct {
+	Bingos int `json:"bingos"`
+	Bangos int `json:"bangos"`
+	Bongos int `json:"bongos"`
+	Points int `json:"points"`
+}
+
+// Junk items. Birdies, eagles and chip-ins are detected from scores, and
+// the rest are tagged by hand.
+const (
+	JunkBirdie  = "birdie"
+	JunkEagle   = "eagle"   // Eagle or better
+	JunkChipIn  = "chip-in" // Holed from off the green
+	JunkSandy   = "sandy"   // Par or better after being in a bunker
+	JunkGreenie = "greenie" // Closest to the pin in regulation on a par 3
+	JunkBarkie  = "barkie"  // Par or better after hitting a tree
+	JunkPolie   = "polie"   // Holed a putt longer than the flagstick
+)
+
+// DefaultJunkTable returns the points for each junk item when a game's
+// junk has not been set up
+func DefaultJunkTable() map[string]int {
+	return map[string]int{
+		JunkBirdie:  1,
+		JunkEagle:   2,
+		JunkChipIn:  1,
+		JunkSandy:   1,
+		JunkGreenie: 1,
+		JunkBarkie:  1,
+		JunkPolie:   1,
+	}
+}
+
+// JunkSetupRequest configures which junk items count and what they are worth
+type JunkSetupRequest struct {
+	Items      map[string]int `json:"items,omitempty"`       // Points by item; items left out do not count
+	PointValue *float64       `json:"point_value,omitempty"` // Dollars each point collects from every other player
+}
+
+// JunkEventRequest tags a player's hand-flagged junk on a hole, replacing
+// anything tagged for them on that hole before
+type JunkEventRequest struct {
+	Hole     int      `json:"hole"`
+	PlayerID string   `json:"player_id"`
+	Items    []string `json:"items"`
+}
+
+// JunkEvent represents a junk item earned on a hole
+type JunkEvent struct {
+	Hole      int           `json:"hole"`
+	Player    PlayerSummary `json:"player"`
+	Item      string        `json:"item"`
+	Points    int           `json:"points"`
+	Automatic bool          `json:"automatic"` // Detected from the score rather than tagged
+}
+
+// JunkResult represents a player's junk points and money
+type JunkResult struct {
+	Player   PlayerSummary  `json:"player"`
+	Items    map[string]int `json:"items"` // Count of each item earned
+	Points   int            `json:"points"`
+	Amount   float64        `json:"amount"` // Net dollars won or lost
+	Position int            `json:"position"`
+}
+
+// JunkStandings represents the junk standings for a game
+type JunkStandings struct {
+	BetType    SideBetType    `json:"bet_type"`
+	Status     GameStatus     `json:"status"`
+	Table      map[string]int `json:"table"`
+	PointValue float64        `json:"point_value"`
+	Players    []JunkResult   `json:"players"`
+	Events     []JunkEvent    `json:"events"`
+}
+
+// JunkCalculationData represents stored calculation data for junk
+type JunkCalculationData struct {
+	Items  map[string]int `json:"items"`
+	Points int            `json:"points"`
+	Amount float64        `json:"amount"`
+}
+
+// SnakePass represents the snake changing hands on a three-putt
+type SnakePass struct {
+	Hole   int           `json:"hole"`
+	Player PlayerSummary `json:"player"` // New holder
+	Putts  int           `json:"putts"`
+	Pot    float64       `json:"pot"` // Pot after the pass
+}
+
+// SnakePayout represents a player's net snake result in dollars
+type SnakePayout struct {
+	Player PlayerSummary `json:"player"`
+	Amount float64       `json:"amount"`
+}
+
+// SnakeStandings represents the snake standings for a game
+type SnakeStandings struct {
+	BetType    SideBetType    `json:"bet_type"`
+	Status     GameStatus     `json:"status"`
+	PassValue  float64        `json:"pass_value"`
+	Holder     *PlayerSummary `json:"holder,omitempty"` // Omitted until someone three-putts
+	Pot        float64        `json:"pot"`
+	Passes     []SnakePass    `json:"passes"`
+	Settlement []SnakePayout  `json:"settlement"` // What each player would pay or collect if the round ended now
+}
+
+// SnakeUpdate represents the snake after a score
+type SnakeUpdate struct {
+	HolderID *string `json:"holder_id,omitempty"`
+	Pot      float64 `json:"pot"`
+	Passed   bool    `json:"passed"` // The scored hole passed the snake to this player
+}
+
+// SnakeSettlement represents the final snake results
+type SnakeSettlement struct {
+	Holder     *PlayerSummary `json:"holder,omitempty"`
+	Pot        float64        `json:"pot"`
+	Settlement []SnakePayout  `json:"settlement"`
+}
+
+// SnakeCalculationData represents stored calculation data for snake
+type SnakeCalculationData struct {
+	Holder bool    `json:"holder"`
+	Passes int     `json:"passes"` // Times the player took the snake
+	Amount float64 `json:"amount"`
+}
+
+// VegasSide represents one two-player Vegas team
+type VegasSide struct {
+	Side    string          `json:"side"` // a or b
+	Name    string          `json:"name"`
+	Players []PlayerSummary `json:"players"`
+}
+
+// VegasHoleResult represents the two teams' numbers on a hole
+type VegasHoleResult struct {
+	Hole    int     `json:"hole"`
+	SideA   int     `json:"side_a"` // Two-digit number, e.g. 45 for a 4 and a 5
+	SideB   int     `json:"side_b"`
+	Flipped *string `json:"flipped,omitempty"` // Side whose number was flipped by the other side's birdie
+	Winner  string  `json:"winner"`            // a, b or halved
+	Points  int     `json:"points"`            // Difference between the numbers, won by the lower
+	Running int     `json:"running"`           // Side a's points after the hole, negative when side b leads
+}
+
+// VegasPayout represents a player's net Vegas result in dollars
+type VegasPayout struct {
+	Player PlayerSummary `json:"player"`
+	Amount float64       `json:"amount"`
+}
+
+// VegasStandings represents the Vegas game for a game
+type VegasStandings struct {
+	BetType         SideBetType       `json:"bet_type"`
+	Status          GameStatus        `json:"status"`
+	HandicapEnabled bool              `json:"handicap_enabled"`
+	PointValue      float64           `json:"point_value"`
+	Sides           []VegasSide       `json:"sides"`
+	Strokes         map[string]int    `json:"strokes,omitempty"` // Handicap strokes received by player ID
+	Thru            int               `json:"thru"`
+	Leader          *string           `json:"leader,omitempty"` // Side ahead on points, omitted when level
+	Points          int               `json:"points"`           // Leader's margin in points
+	Holes           []VegasHoleResult `json:"holes"`
+	Settlement      []VegasPayout     `json:"settlement"`
+}
+
+// VegasSettlement represents the final Vegas results
+type VegasSettlement struct {
+	Leader     *string       `json:"leader,omitempty"`
+	Points     int           `json:"points"`
+	Settlement []VegasPayout `json:"settlement"`
+}
+
+// VegasSetupRequest configures the teams and point value of a Vegas game
+type VegasSetupRequest struct {
+	Sides      [][]string `json:"sides"` // Two sides of two player IDs each
+	PointValue *float64   `json:"point_value,omitempty"`
+}
+
+// VegasCalculationData represents stored calculation data for Vegas
+type VegasCalculationData struct {
+	Side   string  `json:"side"`
+	Points int     `json:"points"` // Points won, negative when the side is behind
+	Amount float64 `json:"amount"`
+}
+
 // SideBetCalculation represents stored side bet calculation data
 type SideBetCalculation struct {
 	ID               string          `json:"id" db:"id"`
@@ -169,11 +576,11 @@ type BestNineCalculationData struct {
 
 // HoleScore represents a score used in calculations
 type HoleScore struct {
-	Hole           int  `json:"hole"`
-	Strokes        int  `json:"strokes"`
-	Par            int  `json:"par"`
-	ScoreToPar     int  `json:"score_to_par"`
-	HandicapStroke bool `json:"handicap_stroke"`
+	Hole            int `json:"hole"`
+	Strokes         int `json:"strokes"`
+	Par             int `json:"par"`
+	ScoreToPar      int `json:"score_to_par"`
+	HandicapStrokes int `json:"handicap_strokes"`
 }
 
 // PuttPuttPokerCalculationData represents stored calculation data for Putt Putt Poker
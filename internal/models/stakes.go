@@ -0,0 +1,16 @@
+package models
+
+// Stakes represents what a game's side bets are played for
+type Stakes struct {
+	PointsOnly bool                     `json:"points_only"` // Standings are kept but no money changes hands
+	Bets       map[SideBetType]BetStake `json:"bets,omitempty"`
+}
+
+// BetStake represents the amounts one side bet is played for. Each side bet
+// uses only some of the fields.
+type BetStake struct {
+	BuyIn       *float64 `json:"buy_in,omitempty"`       // Paid into the pot by each player
+	Value       *float64 `json:"value,omitempty"`        // Per skin, Nassau match, junk point, snake pass or Vegas point
+	Penalty     *float64 `json:"penalty,omitempty"`      // Added to the pot for each three-putt or worse
+	PayoutSplit []int    `json:"payout_split,omitempty"` // Percent of the pot for each finishing place, e.g. [70, 30]
+}
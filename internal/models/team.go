@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+)
+
+// TeamFormat represents how a team's score is made up
+type TeamFormat string
+
+const (
+	TeamBestBall TeamFormat = "best-ball"
+	TeamScramble TeamFormat = "scramble"
+	TeamShamble  TeamFormat = "shamble"
+)
+
+// Team represents a group of players competing together in a game
+type Team struct {
+	ID        string          `json:"id" db:"id"`
+	GameID    string          `json:"game_id" db:"game_id"`
+	Name      string          `json:"name" db:"name"`
+	Position  int             `json:"position" db:"position"`
+	Players   []PlayerSummary `json:"players"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// TeamsResponse represents the response when getting all teams
+type TeamsResponse struct {
+	TeamFormat TeamFormat `json:"team_format"`
+	Teams      []Team     `json:"teams"`
+	TotalCount int        `json:"total_count"`
+}
+
+// TeamSummary represents a condensed team view
+type TeamSummary struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Players []PlayerSummary `json:"players"`
+}
+
+// TeamScore represents a scramble team's score for a specific hole
+type TeamScore struct {
+	ID         string     `json:"id" db:"id"`
+	TeamID     string     `json:"team_id" db:"team_id"`
+	GameID     string     `json:"game_id" db:"game_id"`
+	Hole       int        `json:"hole" db:"hole"`
+	Strokes    int        `json:"strokes" db:"strokes"`
+	Putts      int        `json:"putts" db:"putts"`
+	Par        int        `json:"par" db:"par"`
+	ScoreToPar string     `json:"score_to_par"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// TeamHoleScore represents the score a team counts on a hole
+type TeamHoleScore struct {
+	Hole       int     `json:"hole"`
+	Par        int     `json:"par"`
+	Strokes    int     `json:"strokes"` // Net when handicaps are enabled for best ball and shamble
+	ScoreToPar string  `json:"score_to_par"`
+	PlayerID   *string `json:"player_id,omitempty"` // Whose ball counted, for best ball and shamble
+}
+
+// ScorecardTeam represents team information with counted scores for scorecard
+type ScorecardTeam struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Position int             `json:"position"`
+	Players  []PlayerSummary `json:"players"`
+	Scores   []TeamHoleScore `json:"scores"`
+	Totals   *PlayerStats    `json:"totals"`
+}
+
+// TeamScorecard represents the team view of the game scorecard
+type TeamScorecard struct {
+	Game       GameSummary     `json:"game"`
+	TeamFormat TeamFormat      `json:"team_format"`
+	CourseInfo []HoleInfo      `json:"course_info"`
+	Teams      []ScorecardTeam `json:"teams"`
+}
+
+// TeamLeaderboardEntry represents a team's position in the leaderboard
+type TeamLeaderboardEntry struct {
+	Position       int         `json:"position"`
+	Team           TeamSummary `json:"team"`
+	Score          string      `json:"score"`
+	HolesCompleted int         `json:"holes_completed"`
+}
+
+// TeamLeaderboard represents the team view of the game standings
+type TeamLeaderboard struct {
+	TeamFormat TeamFormat             `json:"team_format"`
+	Teams      []TeamLeaderboardEntry `json:"teams"`
+}
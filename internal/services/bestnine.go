@@ -0,0 +1,421 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+
+	"golf-gamez/internal/models"
+)
+
+const (
+	// bestNineHoles is the number of holes counted toward Best Nine
+	bestNineHoles = 9
+)
+
+// BestNineBet scores each player on their best nine holes relative to par
+type BestNineBet struct {
+	sideBetStore
+	buyIn float64
+}
+
+// NewBestNineBet creates the Best Nine side bet with each player's buy-in
+func NewBestNineBet(db *sql.DB, buyIn float64) *BestNineBet {
+	return &BestNineBet{
+		sideBetStore: sideBetStore{db: db},
+		buyIn:        buyIn,
+	}
+}
+
+// Type returns the Best Nine side bet type
+func (s *BestNineBet) Type() models.SideBetType {
+	return models.SideBetBestNine
+}
+
+// DefaultStake returns the configured buy-in, with the winner taking the pot
+func (s *BestNineBet) DefaultStake() models.BetStake {
+	buyIn := s.buyIn
+	return models.BetStake{BuyIn: &buyIn, PayoutSplit: []int{100}}
+}
+
+// InitializePlayer returns an empty Best Nine calculation
+func (s *BestNineBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.BestNineCalculationData{
+		BestHoles:  []int{},
+		WorstHoles: []int{},
+		UsedScores: []models.HoleScore{},
+	}, nil
+}
+
+// ApplyScore keeps stored Best Nine calculations current as scores come in
+func (s *BestNineBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	return s.Recalculate(gameID)
+}
+
+// Recalculate rebuilds the stored Best Nine calculations
+func (s *BestNineBet) Recalculate(gameID string) error {
+	game, err := s.getGameForSideBet(gameID, models.SideBetBestNine)
+	if err != nil {
+		return err
+	}
+
+	results, calculations, err := s.calculateBestNine(gameID, game.HandicapEnabled)
+	if err != nil {
+		return err
+	}
+
+	return s.saveSideBetCalculations(s.db, gameID, bestNineCalculations(results, calculations, false))
+}
+
+// Standings returns the Best Nine side bet standings
+func (s *BestNineBet) Standings(gameID string) (interface{}, error) {
+	// Verify game exists and has Best Nine enabled
+	game, err := s.getGameForSideBet(gameID, models.SideBetBestNine)
+	if err != nil {
+		return nil, err
+	}
+
+	results, calculations, err := s.calculateBestNine(gameID, game.HandicapEnabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate best nine: %w", err)
+	}
+
+	standings := &models.BestNineStandings{
+		BetType:         models.SideBetBestNine,
+		Status:          game.Status,
+		HandicapEnabled: game.HandicapEnabled,
+		Standings:       results,
+	}
+
+	// The winner is decided when the game is completed, tiebreak included
+	if game.Status == models.GameStatusCompleted {
+		finalResults, err := s.getFinalResults(gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load final results: %w", err)
+		}
+		if finalResults != nil && finalResults.BestNineWinner != nil {
+			standings.Winner = bestNineWinner(finalResults.BestNineWinner, results, calculations)
+		}
+	}
+
+	return standings, nil
+}
+
+// Finalize adds the Best Nine winner to the final results and returns the
+// final positions
+func (s *BestNineBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetBestNine)
+	if err != nil {
+		return nil, err
+	}
+
+	standings, calculations, err := s.calculateBestNine(gameID, game.HandicapEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(standings) > 0 {
+		leaders, err := s.bestNineLeaders(standings, game.HandicapEnabled)
+		if err != nil {
+			return nil, err
+		}
+		results.BestNineWinner = decideWinner(models.PlayoffBestNine, standings[0].FinalScore, leaders, game.Tiebreak, false, results)
+	}
+
+	return bestNineCalculations(standings, calculations, true), nil
+}
+
+// bestNineLeaders returns the players tied for first with their whole
+// rounds, which a card playoff compares
+func (s *BestNineBet) bestNineLeaders(standings []models.BestNineResult, handicapEnabled bool) ([]roundResult, error) {
+	var leaders []roundResult
+	for _, standing := range standings {
+		if standing.Position != 1 {
+			break
+		}
+
+		scores, err := s.getPlayerHoleScores(standing.Player.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		leader := roundResult{
+			playerID:       standing.Player.ID,
+			holesCompleted: standing.HolesCompleted,
+			holes:          make(map[int]float64, len(scores)),
+		}
+		if handicapEnabled {
+			leader.handicap = float64(playingHandicap(standing.Player))
+		}
+		for _, score := range scores {
+			leader.holes[score.Hole] = float64(score.ScoreToPar)
+		}
+		leaders = append(leaders, leader)
+	}
+	return leaders, nil
+}
+
+// Projection plays Best Nine forward from each player's holes
+func (s *BestNineBet) Projection(gameID string, rounds []*projectedRound) (*roundProjection, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetBestNine)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetBestNine)
+	if err != nil {
+		return nil, err
+	}
+	rounds = sideBetRounds(rounds, players)
+
+	return &roundProjection{
+		result: string(models.SideBetBestNine),
+		rounds: rounds,
+		score: func(cards []map[int]roundHole) []float64 {
+			finals := make([]float64, len(cards))
+			for i, card := range cards {
+				scores := make([]models.HoleScore, 0, len(card))
+				for hole, score := range card {
+					scores = append(scores, models.HoleScore{Hole: hole, Strokes: score.strokes, Par: score.par, ScoreToPar: score.strokes - score.par})
+				}
+
+				handicap := 0.0
+				if game.HandicapEnabled {
+					handicap = rounds[i].handicap
+				}
+				finals[i] = float64(calculateBestNineData(scores, handicap).FinalScore)
+			}
+			return finals
+		},
+		format: func(value float64) string {
+			return models.FormatScoreToPar(int(value), 0)
+		},
+	}, nil
+}
+
+// Settle pays every player's buy-in into a pot split by finishing place.
+// Players tied on a place share the places they fill.
+func (s *BestNineBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetBestNine)
+	if err != nil {
+		return nil, err
+	}
+
+	results, _, err := s.calculateBestNine(gameID, game.HandicapEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	stake, err := s.getBetStake(gameID, models.SideBetBestNine, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]int, len(results))
+	for i, result := range results {
+		positions[i] = result.Position
+	}
+	shares := payoutShares(stake.buyIn*float64(len(results)), stake.payoutSplit, positions)
+
+	amounts := make([]models.SettlementAmount, 0, len(results))
+	for i, result := range results {
+		amounts = append(amounts, models.SettlementAmount{Player: result.Player, Amount: roundCurrency(shares[i] - stake.buyIn)})
+	}
+	return amounts, nil
+}
+
+// calculateBestNine ranks every player in a game by their best nine holes.
+// The returned calculations are keyed by player ID.
+func (s *BestNineBet) calculateBestNine(gameID string, handicapEnabled bool) ([]models.BestNineResult, map[string]*models.BestNineCalculationData, error) {
+	players, err := s.getSideBetPlayers(gameID, models.SideBetBestNine)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]models.BestNineResult, 0, len(players))
+	calculations := make(map[string]*models.BestNineCalculationData, len(players))
+	projections := make(map[string]*int, len(players))
+
+	for _, player := range players {
+		scores, err := s.getPlayerHoleScores(player.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		handicap := 0.0
+		if handicapEnabled {
+			handicap = float64(playingHandicap(player))
+		}
+
+		data := calculateBestNineData(scores, handicap)
+		calculations[player.ID] = data
+		projections[player.ID] = projectBestNine(scores, handicap)
+
+		result := models.BestNineResult{
+			Player:             player,
+			BestNineScore:      models.FormatScoreToPar(data.FinalScore, 0),
+			HolesCompleted:     len(scores),
+			BestHoles:          data.BestHoles,
+			WorstHoles:         data.WorstHoles,
+			RawBestNine:        models.FormatScoreToPar(data.RawScore, 0),
+			HandicapAdjustment: models.FormatScoreToPar(data.HandicapAdjustment, 0),
+			FinalScore:         models.FormatScoreToPar(data.FinalScore, 0),
+		}
+		if projected := projections[player.ID]; projected != nil {
+			formatted := models.FormatScoreToPar(*projected, 0)
+			result.ProjectedFinal = &formatted
+		}
+
+		results = append(results, result)
+	}
+
+	// Lowest final score wins; players further into the round rank ahead on ties
+	sort.SliceStable(results, func(i, j int) bool {
+		a := calculations[results[i].Player.ID]
+		b := calculations[results[j].Player.ID]
+		if a.FinalScore != b.FinalScore {
+			return a.FinalScore < b.FinalScore
+		}
+		return results[i].HolesCompleted > results[j].HolesCompleted
+	})
+
+	for i := range results {
+		if i > 0 {
+			prev := calculations[results[i-1].Player.ID]
+			curr := calculations[results[i].Player.ID]
+			if prev.FinalScore == curr.FinalScore && results[i-1].HolesCompleted == results[i].HolesCompleted {
+				results[i].Position = results[i-1].Position
+				continue
+			}
+		}
+		results[i].Position = i + 1
+	}
+
+	return results, calculations, nil
+}
+
+// calculateBestNineData picks the best nine holes relative to par from the
+// holes played so far and applies the Best Nine handicap allowance.
+func calculateBestNineData(scores []models.HoleScore, handicap float64) *models.BestNineCalculationData {
+	sorted := make([]models.HoleScore, len(scores))
+	copy(sorted, scores)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ScoreToPar != sorted[j].ScoreToPar {
+			return sorted[i].ScoreToPar < sorted[j].ScoreToPar
+		}
+		return sorted[i].Hole < sorted[j].Hole
+	})
+
+	count := bestNineHoles
+	if len(sorted) < count {
+		count = len(sorted)
+	}
+
+	data := &models.BestNineCalculationData{
+		BestHoles:  []int{},
+		WorstHoles: []int{},
+		UsedScores: []models.HoleScore{},
+	}
+
+	for i, score := range sorted {
+		if i < count {
+			data.BestHoles = append(data.BestHoles, score.Hole)
+			data.UsedScores = append(data.UsedScores, score)
+			data.RawScore += score.ScoreToPar
+		} else {
+			data.WorstHoles = append(data.WorstHoles, score.Hole)
+		}
+	}
+	sort.Ints(data.BestHoles)
+	sort.Ints(data.WorstHoles)
+
+	data.HandicapAdjustment = bestNineHandicapAdjustment(handicap)
+	data.FinalScore = data.RawScore + data.HandicapAdjustment
+
+	return data
+}
+
+// bestNineHandicapAdjustment returns the strokes deducted for Best Nine,
+// which is 50% of the full handicap rounded to the nearest whole stroke.
+// A plus handicap adds strokes instead.
+func bestNineHandicapAdjustment(handicap float64) int {
+	return -int(math.Round(handicap / 2))
+}
+
+// projectBestNine estimates a partial round's final Best Nine score by
+// assuming the remaining holes are played at the player's average so far.
+// Returns nil for rounds that have not started or are already complete.
+func projectBestNine(scores []models.HoleScore, handicap float64) *int {
+	if len(scores) == 0 || len(scores) >= roundHoles {
+		return nil
+	}
+
+	total := 0
+	values := make([]int, 0, roundHoles)
+	for _, score := range scores {
+		total += score.ScoreToPar
+		values = append(values, score.ScoreToPar)
+	}
+
+	average := int(math.Round(float64(total) / float64(len(scores))))
+	for len(values) < roundHoles {
+		values = append(values, average)
+	}
+
+	sort.Ints(values)
+	projected := bestNineHandicapAdjustment(handicap)
+	for _, value := range values[:bestNineHoles] {
+		projected += value
+	}
+
+	return &projected
+}
+
+// bestNineWinner builds the winner summary for the final results' winner,
+// with their margin over the next best player
+func bestNineWinner(final *models.Winner, results []models.BestNineResult, calculations map[string]*models.BestNineCalculationData) *models.BestNineWinner {
+	winner := &models.BestNineWinner{
+		PlayerID: final.PlayerID,
+		Score:    final.Score,
+		Margin:   "uncontested",
+	}
+
+	if len(results) < 2 {
+		return winner
+	}
+
+	margin := math.MaxInt
+	for _, result := range results {
+		if result.Player.ID != final.PlayerID {
+			margin = min(margin, calculations[result.Player.ID].FinalScore-calculations[final.PlayerID].FinalScore)
+		}
+	}
+
+	switch {
+	case margin <= 0:
+		winner.Margin = "tied"
+	case margin == 1:
+		winner.Margin = "1 stroke"
+	default:
+		winner.Margin = fmt.Sprintf("%d strokes", margin)
+	}
+
+	return winner
+}
+
+// bestNineCalculations builds the stored Best Nine results
+func bestNineCalculations(results []models.BestNineResult, data map[string]*models.BestNineCalculationData, final bool) []sideBetCalculation {
+	calculations := make([]sideBetCalculation, 0, len(results))
+	for _, result := range results {
+		calculations = append(calculations, sideBetCalculation{
+			playerID: result.Player.ID,
+			betType:  models.SideBetBestNine,
+			data:     data[result.Player.ID],
+			position: result.Position,
+			final:    final,
+			isWinner: final && result.Position == 1,
+		})
+	}
+	return calculations
+}
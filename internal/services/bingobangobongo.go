@@ -0,0 +1,263 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// BingoBangoBongoBet awards a point on every hole for each of being first
+// on the green (bingo), closest to the pin once every ball is on (bango)
+// and first in the hole (bongo). The awards are recorded by the scorer
+// alongside the hole's scores, so they do not depend on strokes.
+type BingoBangoBongoBet struct {
+	sideBetStore
+}
+
+// NewBingoBangoBongoBet creates the Bingo Bango Bongo side bet
+func NewBingoBangoBongoBet(db *sql.DB) *BingoBangoBongoBet {
+	return &BingoBangoBongoBet{sideBetStore: sideBetStore{db: db}}
+}
+
+// Type returns the Bingo Bango Bongo side bet type
+func (s *BingoBangoBongoBet) Type() models.SideBetType {
+	return models.SideBetBingoBangoBongo
+}
+
+// InitializePlayer returns an empty Bingo Bango Bongo calculation
+func (s *BingoBangoBongoBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.BingoBangoBongoCalculationData{}, nil
+}
+
+// ApplyScore does nothing, since points come from recorded awards rather
+// than scores
+func (s *BingoBangoBongoBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	return nil
+}
+
+// Recalculate rebuilds the stored point totals
+func (s *BingoBangoBongoBet) Recalculate(gameID string) error {
+	standings, err := s.calculateBingoBangoBongo(gameID)
+	if err != nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, bingoBangoBongoCalculations(standings.Players, false))
+}
+
+// Standings returns point totals and the awards on each recorded hole
+func (s *BingoBangoBongoBet) Standings(gameID string) (interface{}, error) {
+	standings, err := s.calculateBingoBangoBongo(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// Finalize adds every player's Bingo Bango Bongo points to the final results
+func (s *BingoBangoBongoBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateBingoBangoBongo(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	results.BingoBangoBongo = standings.Players
+	return bingoBangoBongoCalculations(standings.Players, true), nil
+}
+
+// Actions exposes the endpoint for recording each hole's awards
+func (s *BingoBangoBongoBet) Actions() []SideBetAction {
+	return []SideBetAction{
+		{
+			Method: http.MethodPost,
+			Path:   "/events",
+			Handle: s.RecordEvents,
+		},
+	}
+}
+
+// RecordEvents records who earned bingo, bango and bongo on a hole. Posting
+// a hole again replaces its awards, so mistakes can be corrected until the
+// game is completed.
+func (s *BingoBangoBongoBet) RecordEvents(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetBingoBangoBongo)
+	if err != nil {
+		return nil, err
+	}
+
+	switch game.Status {
+	case models.GameStatusInProgress:
+	case models.GameStatusCompleted:
+		return nil, errors.New(errors.ErrGameAlreadyCompleted, "Cannot record bingo bango bongo awards after the game is completed")
+	default:
+		return nil, errors.BusinessLogicError(
+			errors.ErrGameNotStarted,
+			"Cannot record bingo bango bongo awards for game that is not in progress",
+			string(game.Status),
+			string(models.GameStatusInProgress),
+		)
+	}
+
+	var req models.BingoBangoBongoEventRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	if req.Hole < 1 || req.Hole > roundHoles {
+		return nil, errors.ValidationError("hole", fmt.Sprintf("%d", req.Hole), "must be between 1 and 18")
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetBingoBangoBongo)
+	if err != nil {
+		return nil, err
+	}
+	inGame := make(map[string]bool, len(players))
+	for _, player := range players {
+		inGame[player.ID] = true
+	}
+
+	awards := []struct {
+		field    string
+		playerID *string
+	}{
+		{"bingo", req.Bingo},
+		{"bango", req.Bango},
+		{"bongo", req.Bongo},
+	}
+	for _, award := range awards {
+		if award.playerID != nil && !inGame[*award.playerID] {
+			return nil, errors.ValidationError(award.field, *award.playerID, "must be playing this side bet")
+		}
+	}
+
+	now := time.Now()
+	_, err = s.db.Exec(`
+		INSERT INTO bingo_bango_bongo_events (game_id, hole, bingo_player_id, bango_player_id, bongo_player_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(game_id, hole) DO UPDATE SET
+			bingo_player_id = excluded.bingo_player_id,
+			bango_player_id = excluded.bango_player_id,
+			bongo_player_id = excluded.bongo_player_id,
+			updated_at = ?
+	`, gameID, req.Hole, req.Bingo, req.Bango, req.Bongo, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save bingo bango bongo awards: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save bingo bango bongo calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// calculateBingoBangoBongo totals each player's awards
+func (s *BingoBangoBongoBet) calculateBingoBangoBongo(gameID string) (*models.BingoBangoBongoStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetBingoBangoBongo)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetBingoBangoBongo)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.PlayerSummary, len(players))
+	results := make(map[string]*models.BingoBangoBongoResult, len(players))
+	for _, player := range players {
+		byID[player.ID] = player
+		results[player.ID] = &models.BingoBangoBongoResult{Player: player}
+	}
+
+	rows, err := s.db.Query(`
+		SELECT hole, bingo_player_id, bango_player_id, bongo_player_id
+		FROM bingo_bango_bongo_events
+		WHERE game_id = ?
+		ORDER BY hole
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	standings := &models.BingoBangoBongoStandings{
+		BetType: models.SideBetBingoBangoBongo,
+		Status:  game.Status,
+		Holes:   []models.BingoBangoBongoHole{},
+	}
+
+	for rows.Next() {
+		var hole models.BingoBangoBongoHole
+		var bingo, bango, bongo sql.NullString
+		if err := rows.Scan(&hole.Hole, &bingo, &bango, &bongo); err != nil {
+			return nil, err
+		}
+
+		// award returns the awarded player and counts the point
+		award := func(playerID sql.NullString, count func(*models.BingoBangoBongoResult)) *models.PlayerSummary {
+			result, ok := results[playerID.String]
+			if !playerID.Valid || !ok {
+				return nil
+			}
+			count(result)
+			result.Points++
+			player := byID[playerID.String]
+			return &player
+		}
+		hole.Bingo = award(bingo, func(r *models.BingoBangoBongoResult) { r.Bingos++ })
+		hole.Bango = award(bango, func(r *models.BingoBangoBongoResult) { r.Bangos++ })
+		hole.Bongo = award(bongo, func(r *models.BingoBangoBongoResult) { r.Bongos++ })
+
+		standings.Holes = append(standings.Holes, hole)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	standings.Players = make([]models.BingoBangoBongoResult, 0, len(players))
+	for _, player := range players {
+		standings.Players = append(standings.Players, *results[player.ID])
+	}
+	sort.SliceStable(standings.Players, func(i, j int) bool {
+		return standings.Players[i].Points > standings.Players[j].Points
+	})
+	for i := range standings.Players {
+		if i > 0 && standings.Players[i].Points == standings.Players[i-1].Points {
+			standings.Players[i].Position = standings.Players[i-1].Position
+			continue
+		}
+		standings.Players[i].Position = i + 1
+	}
+
+	return standings, nil
+}
+
+// bingoBangoBongoCalculations builds the stored point totals
+func bingoBangoBongoCalculations(results []models.BingoBangoBongoResult, final bool) []sideBetCalculation {
+	calculations := make([]sideBetCalculation, 0, len(results))
+	for _, result := range results {
+		data := models.BingoBangoBongoCalculationData{
+			Bingos: result.Bingos,
+			Bangos: result.Bangos,
+			Bongos: result.Bongos,
+			Points: result.Points,
+		}
+		isWinner := final && result.Position == 1 && result.Points > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: result.Player.ID,
+			betType:  models.SideBetBingoBangoBongo,
+			data:     data,
+			position: result.Position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+	return calculations
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// playerCourse is the course as one player plays it: their tee set, rated
+// for their gender, and the par and stroke index of each hole on their card
+type playerCourse struct {
+	tee    models.TeeSet
+	holes  []models.HoleInfo // In hole order
+	byHole map[int]models.HoleInfo
+}
+
+func newPlayerCourse(tee models.TeeSet, holes []models.HoleInfo) *playerCourse {
+	course := &playerCourse{tee: tee, holes: holes, byHole: make(map[int]models.HoleInfo, len(holes))}
+	for _, hole := range holes {
+		course.byHole[hole.Hole] = hole
+	}
+	return course
+}
+
+// par returns the player's par for a hole
+func (c *playerCourse) par(hole int) int {
+	return c.byHole[hole].Par
+}
+
+// ranking returns the player's stroke index for a hole
+func (c *playerCourse) ranking(hole int) int {
+	return c.byHole[hole].HandicapRanking
+}
+
+// pars returns the player's par for every hole
+func (c *playerCourse) pars() map[int]int {
+	pars := make(map[int]int, len(c.holes))
+	for _, hole := range c.holes {
+		pars[hole.Hole] = hole.Par
+	}
+	return pars
+}
+
+// courseHandicap returns the WHS course handicap for a handicap index:
+// index × slope / 113 + (course rating − par), rounded to a whole stroke
+func (c *playerCourse) courseHandicap(index float64) int {
+	return int(math.Round(index*float64(c.tee.SlopeRating)/standardSlope + c.tee.CourseRating - float64(c.tee.Par)))
+}
+
+// ratingGender returns whose ratings a player plays to. Tees and holes are
+// rated for men and women, and players of other or no stated gender use the
+// men's ratings.
+func ratingGender(gender *models.Gender) models.Gender {
+	if gender != nil && *gender == models.GenderFemale {
+		return models.GenderFemale
+	}
+	return models.GenderMale
+}
+
+// findTeeSet looks up a tee set rated for a gender
+func findTeeSet(tees []models.TeeSet, name string, gender models.Gender) (models.TeeSet, bool) {
+	for _, tee := range tees {
+		if tee.Name == name && tee.Gender == gender {
+			return tee, true
+		}
+	}
+	return models.TeeSet{}, false
+}
+
+// validatePlayerTee resolves the tee a player plays from, defaulting to the
+// game's tee. The tee must be rated for the player's gender.
+func validatePlayerTee(tee *string, gameTee string, gender *models.Gender, tees []models.TeeSet) (string, error) {
+	name := gameTee
+	if tee != nil {
+		name = *tee
+	}
+
+	rated := ratingGender(gender)
+	if _, ok := findTeeSet(tees, name, rated); ok {
+		return name, nil
+	}
+
+	allowed := []interface{}{}
+	for _, teeSet := range tees {
+		if teeSet.Gender == rated {
+			allowed = append(allowed, teeSet.Name)
+		}
+	}
+	return "", errors.ValidationErrorWithAllowedValues("tee", name, allowed)
+}
+
+// getCourseHoles loads a course's holes as rated for a gender, in hole order
+func (s *sideBetStore) getCourseHoles(courseName string, gender models.Gender) ([]models.HoleInfo, error) {
+	rows, err := s.db.Query(`
+		SELECT hole, par, handicap_ranking, yardage, description
+		FROM course_data
+		WHERE course_name = ? AND gender = ?
+		ORDER BY hole
+	`, courseName, gender)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holes := []models.HoleInfo{}
+	for rows.Next() {
+		var hole models.HoleInfo
+		var yardage sql.NullInt64
+		var description sql.NullString
+
+		err := rows.Scan(
+			&hole.Hole,
+			&hole.Par,
+			&hole.HandicapRanking,
+			&yardage,
+			&description,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if yardage.Valid {
+			y := int(yardage.Int64)
+			hole.Yardage = &y
+		}
+
+		if description.Valid {
+			hole.Description = description.String
+		}
+
+		holes = append(holes, hole)
+	}
+
+	return holes, rows.Err()
+}
+
+// getPlayerCourses loads the course each player in a game plays, keyed by
+// player ID
+func (s *sideBetStore) getPlayerCourses(gameID string) (map[string]*playerCourse, error) {
+	var courseName string
+	err := s.db.QueryRow("SELECT course FROM games WHERE id = ?", gameID).Scan(&courseName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Game", gameID)
+		}
+		return nil, err
+	}
+
+	tees, err := s.getTeeSets(courseName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query("SELECT id, tee, gender FROM players WHERE game_id = ?", gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type playerTee struct {
+		id, tee string
+		gender  models.Gender
+	}
+	var players []playerTee
+	for rows.Next() {
+		var player playerTee
+		var gender sql.NullString
+		if err := rows.Scan(&player.id, &player.tee, &gender); err != nil {
+			return nil, err
+		}
+		g := models.Gender(gender.String)
+		player.gender = ratingGender(&g)
+		players = append(players, player)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	holes := make(map[models.Gender][]models.HoleInfo, 2)
+	courses := make(map[string]*playerCourse, len(players))
+	for _, player := range players {
+		tee, ok := findTeeSet(tees, player.tee, player.gender)
+		if !ok {
+			return nil, fmt.Errorf("tee %s has no %s rating", player.tee, player.gender)
+		}
+		if _, ok := holes[player.gender]; !ok {
+			if holes[player.gender], err = s.getCourseHoles(courseName, player.gender); err != nil {
+				return nil, err
+			}
+		}
+		courses[player.id] = newPlayerCourse(tee, holes[player.gender])
+	}
+
+	return courses, nil
+}
+
+// getPlayerCourse loads the course one player in a game plays
+func (s *sideBetStore) getPlayerCourse(gameID, playerID string) (*playerCourse, error) {
+	courses, err := s.getPlayerCourses(gameID)
+	if err != nil {
+		return nil, err
+	}
+	course, ok := courses[playerID]
+	if !ok {
+		return nil, errors.ResourceNotFoundError("Player", playerID)
+	}
+	return course, nil
+}
@@ -15,12 +15,13 @@ import (
 
 // GameService handles game-related business logic
 type GameService struct {
-	db *sql.DB
+	db       *sql.DB
+	sideBets *SideBetRegistry
 }
 
 // NewGameService creates a new game service
-func NewGameService(db *sql.DB) *GameService {
-	return &GameService{db: db}
+func NewGameService(db *sql.DB, sideBets *SideBetRegistry) *GameService {
+	return &GameService{db: db, sideBets: sideBets}
 }
 
 // CreateGame creates a new golf game
@@ -41,27 +42,74 @@ func (s *GameService) CreateGame(req *models.CreateGameRequest) (*models.Game, e
 		return nil, errors.ValidationError("course", req.Course, "must be diamond-run")
 	}
 
+	// Validate tee set
+	tees, err := (&sideBetStore{db: s.db}).getTeeSets(req.Course)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tee sets: %w", err)
+	}
+	tee, err := validateTee(req.Tee, tees)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate side bets
-	for _, sideBet := range req.SideBets {
-		if sideBet != models.SideBetBestNine && sideBet != models.SideBetPuttPuttPoker {
-			return nil, errors.ValidationErrorWithAllowedValues(
-				"side_bets",
-				string(sideBet),
-				[]interface{}{models.SideBetBestNine, models.SideBetPuttPuttPoker},
-			)
-		}
+	if err := s.sideBets.Validate(req.SideBets); err != nil {
+		return nil, err
+	}
+
+	// Validate scoring format
+	scoringFormat, stablefordPoints, err := validateScoringFormat(req.ScoringFormat, req.StablefordPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate team format
+	if err := validateTeamFormat(req.TeamFormat, scoringFormat, req.SideBets); err != nil {
+		return nil, err
+	}
+
+	// Validate handicap allowance
+	handicapAllowance, err := validateHandicapAllowance(req.HandicapAllowance, scoringFormat, req.TeamFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate tiebreak policy
+	tiebreak, err := validateTiebreak(req.Tiebreak)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate max score rule
+	maxScore, err := validateMaxScore(req.MaxScore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate stakes
+	stakes, err := validateStakes(req.Stakes, req.SideBets, s.sideBets)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create game object
 	game := &models.Game{
-		ID:              gameID,
-		Course:          req.Course,
-		Status:          models.GameStatusSetup,
-		HandicapEnabled: req.HandicapEnabled,
-		SideBets:        req.SideBets,
-		ShareToken:      tokens.ShareToken,
-		SpectatorToken:  tokens.SpectatorToken,
-		CreatedAt:       time.Now(),
+		ID:                gameID,
+		Course:            req.Course,
+		Tee:               tee,
+		Status:            models.GameStatusSetup,
+		HandicapEnabled:   req.HandicapEnabled,
+		HandicapAllowance: handicapAllowance,
+		ScoringFormat:     scoringFormat,
+		StablefordPoints:  stablefordPoints,
+		TeamFormat:        req.TeamFormat,
+		Tiebreak:          tiebreak,
+		MaxScore:          maxScore,
+		SideBets:          req.SideBets,
+		Stakes:            stakes,
+		ShareToken:        tokens.ShareToken,
+		SpectatorToken:    tokens.SpectatorToken,
+		CreatedAt:         time.Now(),
 	}
 
 	// Marshal side bets
@@ -70,20 +118,40 @@ func (s *GameService) CreateGame(req *models.CreateGameRequest) (*models.Game, e
 		return nil, fmt.Errorf("failed to marshal side bets: %w", err)
 	}
 
+	// Marshal Stableford points
+	stablefordPointsJSON, err := game.MarshalStablefordPoints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Stableford points: %w", err)
+	}
+
+	// Marshal stakes
+	stakesJSON, err := game.MarshalStakes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stakes: %w", err)
+	}
+
 	// Insert into database
 	query := `
 		INSERT INTO games (
-			id, course, status, handicap_enabled, side_bets,
-			share_token, spectator_token, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			id, course, tee, status, handicap_enabled, handicap_allowance, scoring_format, stableford_points,
+			team_format, tiebreak, max_score, side_bets, stakes, share_token, spectator_token, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err = s.db.Exec(
 		query,
 		game.ID,
 		game.Course,
+		game.Tee,
 		game.Status,
 		game.HandicapEnabled,
+		game.HandicapAllowance,
+		game.ScoringFormat,
+		sql.NullString{String: stablefordPointsJSON, Valid: stablefordPointsJSON != ""},
+		game.TeamFormat,
+		game.Tiebreak,
+		game.MaxScore,
 		sideBetsJSON,
+		stakesJSON,
 		game.ShareToken,
 		game.SpectatorToken,
 		game.CreatedAt,
@@ -116,7 +184,7 @@ func (s *GameService) GetGame(gameIDOrToken string) (*models.Game, error) {
 
 	if gameIDOrToken[:3] == "gt_" {
 		query = `
-			SELECT id, course, status, handicap_enabled, side_bets,
+			SELECT id, course, tee, status, handicap_enabled, handicap_allowance, scoring_format, stableford_points, team_format, tiebreak, max_score, side_bets, stakes,
 			       share_token, spectator_token, current_hole,
 			       created_at, started_at, completed_at, final_results
 			FROM games WHERE share_token = ?
@@ -124,7 +192,7 @@ func (s *GameService) GetGame(gameIDOrToken string) (*models.Game, error) {
 		param = gameIDOrToken
 	} else if gameIDOrToken[:3] == "st_" {
 		query = `
-			SELECT id, course, status, handicap_enabled, side_bets,
+			SELECT id, course, tee, status, handicap_enabled, handicap_allowance, scoring_format, stableford_points, team_format, tiebreak, max_score, side_bets, stakes,
 			       share_token, spectator_token, current_hole,
 			       created_at, started_at, completed_at, final_results
 			FROM games WHERE spectator_token = ?
@@ -132,7 +200,7 @@ func (s *GameService) GetGame(gameIDOrToken string) (*models.Game, error) {
 		param = gameIDOrToken
 	} else {
 		query = `
-			SELECT id, course, status, handicap_enabled, side_bets,
+			SELECT id, course, tee, status, handicap_enabled, handicap_allowance, scoring_format, stableford_points, team_format, tiebreak, max_score, side_bets, stakes,
 			       share_token, spectator_token, current_hole,
 			       created_at, started_at, completed_at, final_results
 			FROM games WHERE id = ?
@@ -142,14 +210,25 @@ func (s *GameService) GetGame(gameIDOrToken string) (*models.Game, error) {
 
 	var game models.Game
 	var sideBetsJSON string
+	var stablefordPointsJSON sql.NullString
+	var stakesJSON sql.NullString
+	var teamFormat sql.NullString
 	var finalResultsJSON sql.NullString
 
 	err := s.db.QueryRow(query, param).Scan(
 		&game.ID,
 		&game.Course,
+		&game.Tee,
 		&game.Status,
 		&game.HandicapEnabled,
+		&game.HandicapAllowance,
+		&game.ScoringFormat,
+		&stablefordPointsJSON,
+		&teamFormat,
+		&game.Tiebreak,
+		&game.MaxScore,
 		&sideBetsJSON,
+		&stakesJSON,
 		&game.ShareToken,
 		&game.SpectatorToken,
 		&game.CurrentHole,
@@ -170,6 +249,27 @@ func (s *GameService) GetGame(gameIDOrToken string) (*models.Game, error) {
 		return nil, fmt.Errorf("failed to unmarshal side bets: %w", err)
 	}
 
+	// Unmarshal Stableford points
+	if stablefordPointsJSON.Valid {
+		if err := game.UnmarshalStablefordPoints(stablefordPointsJSON.String); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Stableford points: %w", err)
+		}
+	}
+
+	if teamFormat.Valid {
+		format := models.TeamFormat(teamFormat.String)
+		game.TeamFormat = &format
+	}
+
+	// Unmarshal stakes, filling in defaults for games that predate them
+	if err := game.UnmarshalStakes(stakesJSON.String); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stakes: %w", err)
+	}
+	if game.Stakes == nil {
+		game.Stakes = &models.Stakes{}
+	}
+	game.Stakes = mergeStakes(game.Stakes, game.SideBets, s.sideBets)
+
 	// Unmarshal final results
 	if finalResultsJSON.Valid {
 		if err := game.UnmarshalFinalResults(finalResultsJSON.String); err != nil {
@@ -221,6 +321,33 @@ func (s *GameService) StartGame(gameID string) (*models.Game, error) {
 		return nil, errors.New(errors.ErrInvalidGameState, "Cannot start game without players")
 	}
 
+	// Match play is singles or four-ball
+	if game.ScoringFormat == models.ScoringMatchPlay {
+		if err := checkMatchPlayPlayers(len(game.Players)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Team games need every player on a team
+	if game.TeamFormat != nil {
+		if err := checkTeamPlayers(game.Players); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check side bet requirements
+	for _, sideBetType := range game.SideBets {
+		bet, ok := s.sideBets.Get(sideBetType)
+		if !ok {
+			continue
+		}
+		if checker, ok := bet.(SideBetStartChecker); ok {
+			if err := checker.CheckStart(gameID, sideBetParticipants(game.Players, sideBetType)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Update game status
 	now := time.Now()
 	query := `
@@ -242,6 +369,43 @@ func (s *GameService) StartGame(gameID string) (*models.Game, error) {
 	return s.GetGame(gameID)
 }
 
+// UpdateStakes replaces a game's stakes before it starts. Side bets left out
+// of the request go back to their defaults.
+func (s *GameService) UpdateStakes(gameID string, req *models.Stakes) (*models.Game, error) {
+	game, err := s.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != models.GameStatusSetup {
+		return nil, errors.BusinessLogicError(
+			errors.ErrInvalidGameState,
+			"Stakes can only be changed before the game starts",
+			string(game.Status),
+			string(models.GameStatusSetup),
+		)
+	}
+
+	stakes, err := validateStakes(req, game.SideBets, s.sideBets)
+	if err != nil {
+		return nil, err
+	}
+	game.Stakes = stakes
+
+	stakesJSON, err := game.MarshalStakes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stakes: %w", err)
+	}
+
+	_, err = s.db.Exec("UPDATE games SET stakes = ? WHERE id = ?", stakesJSON, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stakes: %w", err)
+	}
+
+	log.Info().Str("game_id", gameID).Bool("points_only", stakes.PointsOnly).Msg("Game stakes updated")
+	return s.GetGame(gameID)
+}
+
 // CompleteGame marks a game as completed and calculates final results
 func (s *GameService) CompleteGame(gameID string) (*models.GameCompletionResult, error) {
 	game, err := s.GetGame(gameID)
@@ -259,7 +423,7 @@ func (s *GameService) CompleteGame(gameID string) (*models.GameCompletionResult,
 	}
 
 	// Calculate final results
-	finalResults, err := s.calculateFinalResults(gameID)
+	finalResults, calculations, err := s.calculateFinalResults(gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate final results: %w", err)
 	}
@@ -270,6 +434,20 @@ func (s *GameService) CompleteGame(gameID string) (*models.GameCompletionResult,
 		return nil, fmt.Errorf("failed to marshal final results: %w", err)
 	}
 
+	// Work out the settlement before anything is written, so a bet that
+	// fails to settle leaves the game in progress
+	settlements := NewSettlementService(s.db, s.sideBets)
+	bets, err := settlements.settleBets(gameID, game.SideBets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle game: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Update game
 	now := time.Now()
 	query := `
@@ -277,19 +455,106 @@ func (s *GameService) CompleteGame(gameID string) (*models.GameCompletionResult,
 		SET status = ?, completed_at = ?, final_results = ?
 		WHERE id = ?
 	`
-	_, err = s.db.Exec(query, models.GameStatusCompleted, now, string(finalResultsJSON), gameID)
+	_, err = tx.Exec(query, models.GameStatusCompleted, now, string(finalResultsJSON), gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete game: %w", err)
 	}
 
+	if err := settlements.saveSideBetCalculations(tx, gameID, calculations); err != nil {
+		return nil, fmt.Errorf("failed to save side bet results: %w", err)
+	}
+
+	if err := settlements.saveSettlement(tx, gameID, bets); err != nil {
+		return nil, fmt.Errorf("failed to save settlement: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to complete game: %w", err)
+	}
+
+	// Post tracked golfers' rounds to their handicap records. The game is
+	// already complete, so a failure here doesn't undo it.
+	if err := NewGolferService(s.db).postRounds(gameID, now); err != nil {
+		log.Warn().Err(err).Str("game_id", gameID).Msg("Failed to post handicap rounds")
+	}
+
+	settlement, err := settlements.GetSettlement(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settlement: %w", err)
+	}
+
 	return &models.GameCompletionResult{
 		ID:           gameID,
 		Status:       models.GameStatusCompleted,
 		CompletedAt:  now,
 		FinalResults: finalResults,
+		Settlement:   settlement,
 	}, nil
 }
 
+// RecordPlayoff enters the winner of a sudden-death playoff for a result
+// tied at the end of a completed game
+func (s *GameService) RecordPlayoff(gameID, result string, req *models.PlayoffRequest) (*models.FinalResults, error) {
+	game, err := s.GetGame(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != models.GameStatusCompleted {
+		return nil, errors.BusinessLogicError(
+			errors.ErrGameNotCompleted,
+			"Playoffs are entered once the game is completed",
+			string(game.Status),
+			string(models.GameStatusCompleted),
+		)
+	}
+
+	var playoff *models.Playoff
+	if game.FinalResults != nil {
+		for i := range game.FinalResults.Playoffs {
+			if game.FinalResults.Playoffs[i].Result == result {
+				playoff = &game.FinalResults.Playoffs[i]
+			}
+		}
+	}
+	if playoff == nil {
+		return nil, errors.ResourceNotFoundError("Playoff", result)
+	}
+
+	if req.WinnerID == "" {
+		return nil, errors.NewWithDetails(errors.ErrMissingRequiredField, "A playoff winner is required", map[string]interface{}{
+			"field": "winner_id",
+		})
+	}
+	tied := false
+	for _, playerID := range playoff.PlayerIDs {
+		if playerID == req.WinnerID {
+			tied = true
+		}
+	}
+	if !tied {
+		return nil, errors.ValidationError("winner_id", req.WinnerID, "must be one of the tied players")
+	}
+
+	setPlayoffWinner(game.FinalResults, playoff, req.WinnerID)
+
+	finalResultsJSON, err := game.MarshalFinalResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal final results: %w", err)
+	}
+	if _, err := s.db.Exec("UPDATE games SET final_results = ? WHERE id = ?", finalResultsJSON, gameID); err != nil {
+		return nil, fmt.Errorf("failed to record playoff: %w", err)
+	}
+
+	log.Info().
+		Str("game_id", gameID).
+		Str("result", result).
+		Str("winner_id", req.WinnerID).
+		Msg("Playoff winner recorded")
+
+	return game.FinalResults, nil
+}
+
 // DeleteGame deletes a game and all associated data
 func (s *GameService) DeleteGame(gameID string) error {
 	// Verify game exists
@@ -321,10 +586,12 @@ func (s *GameService) DeleteGame(gameID string) error {
 // getGamePlayers loads players for a game
 func (s *GameService) getGamePlayers(gameID string) ([]models.Player, error) {
 	query := `
-		SELECT id, game_id, name, handicap, gender, position, created_at
-		FROM players
-		WHERE game_id = ?
-		ORDER BY position
+		SELECT p.id, p.game_id, p.name, p.handicap, p.gender, p.golfer_id, p.tee, p.position, p.team_id,
+		       COALESCE(p.side_bets, g.side_bets), p.created_at
+		FROM players p
+		JOIN games g ON g.id = p.game_id
+		WHERE p.game_id = ?
+		ORDER BY p.position
 	`
 	rows, err := s.db.Query(query, gameID)
 	if err != nil {
@@ -332,10 +599,16 @@ func (s *GameService) getGamePlayers(gameID string) ([]models.Player, error) {
 	}
 	defer rows.Close()
 
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
 	var players []models.Player
 	for rows.Next() {
 		var player models.Player
 		var gender sql.NullString
+		var sideBetsJSON string
 
 		err := rows.Scan(
 			&player.ID,
@@ -343,7 +616,11 @@ func (s *GameService) getGamePlayers(gameID string) ([]models.Player, error) {
 			&player.Name,
 			&player.Handicap,
 			&gender,
+			&player.GolferID,
+			&player.Tee,
 			&player.Position,
+			&player.TeamID,
+			&sideBetsJSON,
 			&player.CreatedAt,
 		)
 		if err != nil {
@@ -355,6 +632,11 @@ func (s *GameService) getGamePlayers(gameID string) ([]models.Player, error) {
 			player.Gender = &g
 		}
 
+		if err := player.UnmarshalSideBets(sideBetsJSON); err != nil {
+			return nil, err
+		}
+		handicaps.applyToPlayer(&player)
+
 		players = append(players, player)
 	}
 
@@ -363,109 +645,81 @@ func (s *GameService) getGamePlayers(gameID string) ([]models.Player, error) {
 
 // getCourseInfo loads course information
 func (s *GameService) getCourseInfo(courseName string) (*models.CourseInfo, error) {
-	query := `
-		SELECT hole, par, handicap_ranking, yardage, description
-		FROM course_data
-		WHERE course_name = ?
-		ORDER BY hole
-	`
-	rows, err := s.db.Query(query, courseName)
+	store := &sideBetStore{db: s.db}
+
+	holes, err := store.getCourseHoles(courseName, models.GenderMale)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var holes []models.HoleInfo
 	totalPar := 0
+	for _, hole := range holes {
+		totalPar += hole.Par
+	}
 
-	for rows.Next() {
-		var hole models.HoleInfo
-		var yardage sql.NullInt64
-		var description sql.NullString
-
-		err := rows.Scan(
-			&hole.Hole,
-			&hole.Par,
-			&hole.HandicapRanking,
-			&yardage,
-			&description,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if yardage.Valid {
-			y := int(yardage.Int64)
-			hole.Yardage = &y
-		}
-
-		if description.Valid {
-			hole.Description = description.String
-		}
+	femaleHoles, err := store.getCourseHoles(courseName, models.GenderFemale)
+	if err != nil {
+		return nil, err
+	}
 
-		holes = append(holes, hole)
-		totalPar += hole.Par
+	tees, err := store.getTeeSets(courseName)
+	if err != nil {
+		return nil, err
 	}
 
 	return &models.CourseInfo{
-		Name:     "Diamond Run",
-		Holes:    holes,
-		TotalPar: totalPar,
+		Name:        "Diamond Run",
+		Holes:       holes,
+		FemaleHoles: femaleHoles,
+		TotalPar:    totalPar,
+		Tees:        tees,
 	}, nil
 }
 
-// initializeSideBets creates initial side bet calculations for players
-func (s *GameService) initializeSideBets(gameID string, players []models.Player, sideBets []models.SideBetType) error {
+// sideBetParticipants returns the players taking part in a side bet
+func sideBetParticipants(players []models.Player, sideBetType models.SideBetType) []models.Player {
+	var participants []models.Player
 	for _, player := range players {
-		for _, sideBet := range sideBets {
-			sideBetID, err := auth.GenerateSideBetID()
+		if player.PlaysSideBet(sideBetType) {
+			participants = append(participants, player)
+		}
+	}
+	return participants
+}
+
+// initializeSideBets creates initial side bet calculations for the players
+// taking part in each side bet
+func (s *GameService) initializeSideBets(gameID string, players []models.Player, sideBets []models.SideBetType) error {
+	for _, sideBetType := range sideBets {
+		bet, ok := s.sideBets.Get(sideBetType)
+		if !ok {
+			continue
+		}
+
+		for _, player := range sideBetParticipants(players, sideBetType) {
+			data, err := bet.InitializePlayer(gameID, player)
 			if err != nil {
 				return err
 			}
 
-			var calculationData []byte
-			switch sideBet {
-			case models.SideBetBestNine:
-				data := models.BestNineCalculationData{
-					BestHoles:          []int{},
-					WorstHoles:         []int{},
-					RawScore:           0,
-					HandicapAdjustment: 0,
-					FinalScore:         0,
-					UsedScores:         []models.HoleScore{},
-				}
-				calculationData, err = json.Marshal(data)
-			case models.SideBetPuttPuttPoker:
-				data := models.PuttPuttPokerCalculationData{
-					TotalCards:   3, // Starting cards
-					CardsEarned:  0,
-					Penalties:    0,
-					PuttingStats: models.PuttingStats{},
-					CardHistory:  []models.CardEvent{},
-				}
-				calculationData, err = json.Marshal(data)
-
-				// Also create initial poker card record
-				if err == nil {
-					cardID, _ := auth.GeneratePokerCardID()
-					_, err = s.db.Exec(`
-						INSERT INTO putt_putt_poker_cards
-						(id, player_id, game_id, action, cards_change, total_cards)
-						VALUES (?, ?, ?, 'starting', 3, 3)
-					`, cardID, player.ID, gameID)
-				}
+			calculationData, err := json.Marshal(data)
+			if err != nil {
+				return err
 			}
 
+			sideBetID, err := auth.GenerateSideBetID()
 			if err != nil {
 				return err
 			}
 
-			// Insert side bet calculation
+			// Insert side bet calculation, keeping any a side bet's setup
+			// already saved before the game started
 			_, err = s.db.Exec(`
 				INSERT INTO side_bet_calculations
 				(id, game_id, player_id, bet_type, calculation_data)
 				VALUES (?, ?, ?, ?, ?)
-			`, sideBetID, gameID, player.ID, sideBet, string(calculationData))
+				ON CONFLICT(player_id, bet_type) DO NOTHING
+			`, sideBetID, gameID, player.ID, sideBetType, string(calculationData))
 			if err != nil {
 				return err
 			}
@@ -475,9 +729,32 @@ func (s *GameService) initializeSideBets(gameID string, players []models.Player,
 	return nil
 }
 
-// calculateFinalResults calculates final game results
-func (s *GameService) calculateFinalResults(gameID string) (*models.FinalResults, error) {
-	// TODO: Implement final results calculation
-	// This would calculate overall winner, best nine winner, and poker winner
-	return &models.FinalResults{}, nil
-}
\ No newline at end of file
+// calculateFinalResults calculates final game results along with the final
+// side bet calculations to store with them
+func (s *GameService) calculateFinalResults(gameID string) (*models.FinalResults, []sideBetCalculation, error) {
+	game, err := s.GetGame(gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := &models.FinalResults{}
+
+	if err := NewScoreService(s.db, nil).finalizeOverall(gameID, results); err != nil {
+		return nil, nil, fmt.Errorf("failed to rank players: %w", err)
+	}
+
+	var calculations []sideBetCalculation
+	for _, sideBetType := range game.SideBets {
+		bet, ok := s.sideBets.Get(sideBetType)
+		if !ok {
+			continue
+		}
+		betCalculations, err := bet.Finalize(gameID, results)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to finalize %s: %w", sideBetType, err)
+		}
+		calculations = append(calculations, betCalculations...)
+	}
+
+	return results, calculations, nil
+}
@@ -0,0 +1,449 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/auth"
+	"golf-gamez/pkg/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// indexRounds is how many of a golfer's most recent rounds the handicap
+	// index is calculated from
+	indexRounds = 20
+
+	// maxHandicapIndex is the highest handicap index WHS allows
+	maxHandicapIndex = 54.0
+
+	// softCapThreshold is how far an index can rise above the low index
+	// before the increase beyond it is halved
+	softCapThreshold = 3.0
+
+	// hardCapThreshold is the furthest an index can rise above the low index
+	hardCapThreshold = 5.0
+)
+
+// GolferService handles golfers and their handicap index calculations
+type GolferService struct {
+	db *sql.DB
+}
+
+// NewGolferService creates a new golfer service
+func NewGolferService(db *sql.DB) *GolferService {
+	return &GolferService{db: db}
+}
+
+// CreateGolfer starts tracking a golfer's handicap index, optionally from a
+// starting index that stands until three rounds have been posted
+func (s *GolferService) CreateGolfer(req *models.CreateGolferRequest) (*models.Golfer, error) {
+	if req.Name == "" {
+		return nil, errors.ValidationError("name", "", "is required")
+	}
+	if len(req.Name) > 100 {
+		return nil, errors.ValidationError("name", req.Name, "must be 100 characters or less")
+	}
+	if req.HandicapIndex != nil && (*req.HandicapIndex < -10 || *req.HandicapIndex > maxHandicapIndex) {
+		return nil, errors.ValidationError("handicap_index", fmt.Sprintf("%.1f", *req.HandicapIndex), "must be between -10 and 54")
+	}
+
+	golferID, err := auth.GenerateGolferID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate golfer ID: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if _, err := tx.Exec("INSERT INTO golfers (id, name, created_at) VALUES (?, ?, ?)", golferID, req.Name, now); err != nil {
+		return nil, fmt.Errorf("failed to insert golfer: %w", err)
+	}
+
+	if req.HandicapIndex != nil {
+		index := roundToTenth(*req.HandicapIndex)
+		if err := insertIndexChange(tx, golferID, nil, index, nil, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit golfer: %w", err)
+	}
+
+	log.Info().Str("golfer_id", golferID).Str("name", req.Name).Msg("Golfer created successfully")
+
+	return s.GetGolfer(golferID)
+}
+
+// GetGolfer retrieves a golfer with their current handicap index, recent
+// rounds and index history
+func (s *GolferService) GetGolfer(golferID string) (*models.Golfer, error) {
+	golfer := &models.Golfer{
+		ID:      golferID,
+		Rounds:  []models.HandicapRound{},
+		History: []models.HandicapIndexChange{},
+	}
+	err := s.db.QueryRow("SELECT name, created_at FROM golfers WHERE id = ?", golferID).Scan(&golfer.Name, &golfer.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Golfer", golferID)
+		}
+		return nil, fmt.Errorf("failed to get golfer: %w", err)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT handicap_index, low_index, game_id, created_at
+		FROM handicap_index_history
+		WHERE golfer_id = ?
+		ORDER BY rowid DESC
+	`, golferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query handicap index history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.HandicapIndexChange
+		var low sql.NullFloat64
+		var gameID sql.NullString
+		if err := rows.Scan(&change.HandicapIndex, &low, &gameID, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan handicap index change: %w", err)
+		}
+		if low.Valid {
+			change.LowIndex = &low.Float64
+		}
+		if gameID.Valid {
+			change.GameID = &gameID.String
+		}
+		golfer.History = append(golfer.History, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(golfer.History) > 0 {
+		golfer.HandicapIndex = &golfer.History[0].HandicapIndex
+		golfer.LowIndex = golfer.History[0].LowIndex
+	}
+
+	rounds, err := getRecentHandicapRounds(s.db, golferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load handicap rounds: %w", err)
+	}
+	differentials := make([]float64, len(rounds))
+	for i, round := range rounds {
+		differentials[i] = round.Differential
+	}
+	for _, i := range countedRounds(differentials) {
+		rounds[i].Counted = true
+	}
+	golfer.Rounds = append(golfer.Rounds, rounds...)
+
+	return golfer, nil
+}
+
+// currentIndex returns a golfer's latest handicap index, or nil when they
+// have neither a starting index nor enough rounds for one
+func (s *GolferService) currentIndex(golferID string) (*float64, error) {
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM golfers WHERE id = ?)", golferID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.ResourceNotFoundError("Golfer", golferID)
+	}
+
+	var index float64
+	err := s.db.QueryRow(`
+		SELECT handicap_index FROM handicap_index_history
+		WHERE golfer_id = ?
+		ORDER BY rowid DESC
+		LIMIT 1
+	`, golferID).Scan(&index)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// postRounds posts every full round of a completed game played by a tracked
+// golfer and revises their handicap index
+func (s *GolferService) postRounds(gameID string, playedAt time.Time) error {
+	courses, err := (&sideBetStore{db: s.db}).getPlayerCourses(gameID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT p.id, p.golfer_id, p.handicap, s.hole, s.strokes
+		FROM players p
+		JOIN scores s ON s.player_id = p.id
+		WHERE p.game_id = ? AND p.golfer_id IS NOT NULL
+		ORDER BY p.position, s.hole
+	`, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to query golfer scores: %w", err)
+	}
+	defer rows.Close()
+
+	type postedRound struct {
+		golferID      string
+		tee           models.TeeSet
+		holes         int
+		adjustedGross int
+	}
+	var rounds []*postedRound
+	byGolfer := make(map[string]*postedRound)
+	for rows.Next() {
+		var playerID, golferID string
+		var handicap float64
+		var hole, strokes int
+		if err := rows.Scan(&playerID, &golferID, &handicap, &hole, &strokes); err != nil {
+			return fmt.Errorf("failed to scan golfer score: %w", err)
+		}
+		course := courses[playerID]
+		round, ok := byGolfer[golferID]
+		if !ok {
+			round = &postedRound{golferID: golferID, tee: course.tee}
+			byGolfer[golferID] = round
+			rounds = append(rounds, round)
+		}
+		// Posted scores are always capped at net double bogey, whatever
+		// the game's own rule
+		posting := maxScoreCap{rule: models.MaxScoreNetDoubleBogey, course: course, index: handicap}
+		round.holes++
+		round.adjustedGross += posting.adjustedStrokes(hole, strokes)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, round := range rounds {
+		if round.holes < roundHoles {
+			continue
+		}
+
+		roundID, err := auth.GenerateHandicapRoundID()
+		if err != nil {
+			return fmt.Errorf("failed to generate handicap round ID: %w", err)
+		}
+		differential := scoreDifferential(round.adjustedGross, round.tee)
+		_, err = tx.Exec(`
+			INSERT INTO handicap_rounds (id, golfer_id, game_id, played_at, adjusted_gross, course_rating, slope_rating, differential)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`, roundID, round.golferID, gameID, playedAt, round.adjustedGross, round.tee.CourseRating, round.tee.SlopeRating, differential)
+		if err != nil {
+			return fmt.Errorf("failed to insert handicap round: %w", err)
+		}
+
+		if err := reviseHandicapIndex(tx, round.golferID, gameID, playedAt); err != nil {
+			return err
+		}
+
+		log.Info().
+			Str("golfer_id", round.golferID).
+			Str("game_id", gameID).
+			Int("adjusted_gross", round.adjustedGross).
+			Float64("differential", differential).
+			Msg("Handicap round posted")
+	}
+
+	return tx.Commit()
+}
+
+// reviseHandicapIndex recalculates a golfer's handicap index after a round
+// is posted and records it in their history. An index needs at least three
+// rounds, so until then any starting index stands.
+func reviseHandicapIndex(tx *sql.Tx, golferID, gameID string, playedAt time.Time) error {
+	rounds, err := getRecentHandicapRounds(tx, golferID)
+	if err != nil {
+		return fmt.Errorf("failed to load handicap rounds: %w", err)
+	}
+	differentials := make([]float64, len(rounds))
+	for i, round := range rounds {
+		differentials[i] = round.Differential
+	}
+
+	index, ok := handicapIndex(differentials)
+	if !ok {
+		return nil
+	}
+
+	// The caps only apply once a full record of rounds sets a low index
+	var low *float64
+	if len(rounds) == indexRounds {
+		var lowest sql.NullFloat64
+		err := tx.QueryRow(`
+			SELECT MIN(handicap_index) FROM handicap_index_history
+			WHERE golfer_id = ? AND created_at >= ?
+		`, golferID, playedAt.AddDate(-1, 0, 0)).Scan(&lowest)
+		if err != nil {
+			return fmt.Errorf("failed to load low handicap index: %w", err)
+		}
+		if lowest.Valid {
+			low = &lowest.Float64
+			index = capHandicapIndex(index, *low)
+		}
+	}
+
+	return insertIndexChange(tx, golferID, &gameID, index, low, playedAt)
+}
+
+// handicapIndex calculates a handicap index from a golfer's most recent
+// differentials, averaging the lowest of them by how many there are.
+// It reports false when there are fewer than three.
+func handicapIndex(differentials []float64) (float64, bool) {
+	counted := countedRounds(differentials)
+	if len(counted) == 0 {
+		return 0, false
+	}
+
+	var total float64
+	for _, i := range counted {
+		total += differentials[i]
+	}
+	_, adjustment := countedDifferentials(len(differentials))
+	index := roundToTenth(total/float64(len(counted)) + adjustment)
+
+	return math.Min(index, maxHandicapIndex), true
+}
+
+// countedDifferentials returns how many of the lowest differentials a
+// handicap index averages for a number of rounds, and the adjustment made
+// to the average when there are few of them
+func countedDifferentials(rounds int) (int, float64) {
+	switch {
+	case rounds < 3:
+		return 0, 0
+	case rounds == 3:
+		return 1, -2.0
+	case rounds == 4:
+		return 1, -1.0
+	case rounds == 5:
+		return 1, 0
+	case rounds == 6:
+		return 2, -1.0
+	case rounds <= 8:
+		return 2, 0
+	case rounds <= 11:
+		return 3, 0
+	case rounds <= 14:
+		return 4, 0
+	case rounds <= 16:
+		return 5, 0
+	case rounds <= 18:
+		return 6, 0
+	case rounds == 19:
+		return 7, 0
+	default:
+		return 8, 0
+	}
+}
+
+// countedRounds returns the positions of the differentials a handicap index
+// averages, lowest first. Ties go to the more recent round.
+func countedRounds(differentials []float64) []int {
+	count, _ := countedDifferentials(len(differentials))
+	order := make([]int, len(differentials))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return differentials[order[a]] < differentials[order[b]]
+	})
+	return order[:count]
+}
+
+// capHandicapIndex limits how far an index can rise above the golfer's low
+// index: the soft cap halves any increase beyond 3.0 and the hard cap stops
+// it at 5.0
+func capHandicapIndex(index, low float64) float64 {
+	if index-low > softCapThreshold {
+		index = roundToTenth(low + softCapThreshold + (index-low-softCapThreshold)/2)
+	}
+	return math.Min(index, roundToTenth(low+hardCapThreshold))
+}
+
+// scoreDifferential returns how a round compares to the tee set's rating:
+// (113 / slope) × (adjusted gross − course rating), to one decimal place
+func scoreDifferential(adjustedGross int, tee models.TeeSet) float64 {
+	return roundToTenth(standardSlope / float64(tee.SlopeRating) * (float64(adjustedGross) - tee.CourseRating))
+}
+
+func roundToTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// handicapQuerier is satisfied by both *sql.DB and *sql.Tx
+type handicapQuerier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// getRecentHandicapRounds loads the rounds a golfer's handicap index is
+// calculated from, newest first
+func getRecentHandicapRounds(db handicapQuerier, golferID string) ([]models.HandicapRound, error) {
+	rows, err := db.Query(`
+		SELECT game_id, played_at, adjusted_gross, course_rating, slope_rating, differential
+		FROM handicap_rounds
+		WHERE golfer_id = ?
+		ORDER BY played_at DESC, rowid DESC
+		LIMIT ?
+	`, golferID, indexRounds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rounds []models.HandicapRound
+	for rows.Next() {
+		var round models.HandicapRound
+		err := rows.Scan(
+			&round.GameID,
+			&round.PlayedAt,
+			&round.AdjustedGross,
+			&round.CourseRating,
+			&round.SlopeRating,
+			&round.Differential,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rounds = append(rounds, round)
+	}
+
+	return rounds, rows.Err()
+}
+
+func insertIndexChange(tx *sql.Tx, golferID string, gameID *string, index float64, low *float64, at time.Time) error {
+	changeID, err := auth.GenerateHandicapIndexID()
+	if err != nil {
+		return fmt.Errorf("failed to generate handicap index ID: %w", err)
+	}
+	_, err = tx.Exec(`
+		INSERT INTO handicap_index_history (id, golfer_id, game_id, handicap_index, low_index, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, changeID, golferID, gameID, index, low, at)
+	if err != nil {
+		return fmt.Errorf("failed to insert handicap index: %w", err)
+	}
+	return nil
+}
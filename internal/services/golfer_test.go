@@ -0,0 +1,124 @@
+package services
+
+import "testing"
+
+func TestCountedDifferentials(t *testing.T) {
+	tests := []struct {
+		rounds     int
+		count      int
+		adjustment float64
+	}{
+		{rounds: 0, count: 0, adjustment: 0},
+		{rounds: 2, count: 0, adjustment: 0},
+		{rounds: 3, count: 1, adjustment: -2.0},
+		{rounds: 4, count: 1, adjustment: -1.0},
+		{rounds: 5, count: 1, adjustment: 0},
+		{rounds: 6, count: 2, adjustment: -1.0},
+		{rounds: 7, count: 2, adjustment: 0},
+		{rounds: 8, count: 2, adjustment: 0},
+		{rounds: 9, count: 3, adjustment: 0},
+		{rounds: 11, count: 3, adjustment: 0},
+		{rounds: 12, count: 4, adjustment: 0},
+		{rounds: 14, count: 4, adjustment: 0},
+		{rounds: 15, count: 5, adjustment: 0},
+		{rounds: 16, count: 5, adjustment: 0},
+		{rounds: 17, count: 6, adjustment: 0},
+		{rounds: 18, count: 6, adjustment: 0},
+		{rounds: 19, count: 7, adjustment: 0},
+		{rounds: 20, count: 8, adjustment: 0},
+	}
+
+	for _, tt := range tests {
+		count, adjustment := countedDifferentials(tt.rounds)
+		if count != tt.count || adjustment != tt.adjustment {
+			t.Errorf("countedDifferentials(%d) = %d, %.1f, want %d, %.1f", tt.rounds, count, adjustment, tt.count, tt.adjustment)
+		}
+	}
+}
+
+func TestHandicapIndex(t *testing.T) {
+	// Differentials of 10.0, 11.0, 12.0 and so on, so the lowest n average
+	// to 10.0 + (n-1)/2
+	rising := func(rounds int) []float64 {
+		differentials := make([]float64, rounds)
+		for i := range differentials {
+			differentials[i] = 10.0 + float64(i)
+		}
+		return differentials
+	}
+
+	tests := []struct {
+		name          string
+		differentials []float64
+		want          float64
+		ok            bool
+	}{
+		{name: "no rounds", differentials: nil, ok: false},
+		{name: "2 rounds", differentials: rising(2), ok: false},
+		{name: "3 rounds", differentials: rising(3), want: 8.0, ok: true},
+		{name: "4 rounds", differentials: rising(4), want: 9.0, ok: true},
+		{name: "5 rounds", differentials: rising(5), want: 10.0, ok: true},
+		{name: "6 rounds", differentials: rising(6), want: 9.5, ok: true},
+		{name: "7 rounds", differentials: rising(7), want: 10.5, ok: true},
+		{name: "8 rounds", differentials: rising(8), want: 10.5, ok: true},
+		{name: "9 rounds", differentials: rising(9), want: 11.0, ok: true},
+		{name: "11 rounds", differentials: rising(11), want: 11.0, ok: true},
+		{name: "12 rounds", differentials: rising(12), want: 11.5, ok: true},
+		{name: "14 rounds", differentials: rising(14), want: 11.5, ok: true},
+		{name: "15 rounds", differentials: rising(15), want: 12.0, ok: true},
+		{name: "16 rounds", differentials: rising(16), want: 12.0, ok: true},
+		{name: "17 rounds", differentials: rising(17), want: 12.5, ok: true},
+		{name: "18 rounds", differentials: rising(18), want: 12.5, ok: true},
+		{name: "19 rounds", differentials: rising(19), want: 13.0, ok: true},
+		{name: "20 rounds", differentials: rising(20), want: 13.5, ok: true},
+		{name: "lowest in any order", differentials: []float64{18.2, 14.6, 21.0, 15.1, 16.0, 13.9, 19.4}, want: 14.3, ok: true},
+		{name: "plus handicap", differentials: []float64{-1.2, 0.4, -0.6}, want: -3.2, ok: true},
+		{name: "capped at 54", differentials: []float64{61.0, 64.5, 60.2, 63.3, 62.8}, want: 54.0, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := handicapIndex(tt.differentials)
+			if ok != tt.ok {
+				t.Fatalf("handicapIndex(%v) ok = %v, want %v", tt.differentials, ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Errorf("handicapIndex(%v) = %.1f, want %.1f", tt.differentials, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountedRounds(t *testing.T) {
+	// Most recent first, so the tie for lowest goes to position 1
+	got := countedRounds([]float64{12.0, 9.5, 9.5, 14.2})
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("countedRounds = %v, want [1]", got)
+	}
+}
+
+func TestCapHandicapIndex(t *testing.T) {
+	tests := []struct {
+		name  string
+		index float64
+		low   float64
+		want  float64
+	}{
+		{name: "below the low index", index: 8.4, low: 10.0, want: 8.4},
+		{name: "within 3.0", index: 12.5, low: 10.0, want: 12.5},
+		{name: "at the soft cap", index: 13.0, low: 10.0, want: 13.0},
+		{name: "soft cap halves the rest", index: 14.0, low: 10.0, want: 13.5},
+		{name: "soft cap rounds to a tenth", index: 13.3, low: 10.0, want: 13.2},
+		{name: "reaches the hard cap", index: 17.0, low: 10.0, want: 15.0},
+		{name: "held at the hard cap", index: 21.6, low: 10.0, want: 15.0},
+		{name: "plus low index", index: 4.5, low: -1.5, want: 3.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := capHandicapIndex(tt.index, tt.low); got != tt.want {
+				t.Errorf("capHandicapIndex(%.1f, %.1f) = %.1f, want %.1f", tt.index, tt.low, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+const (
+	// standardSlope is the slope rating of a course of standard difficulty
+	standardSlope = 113
+
+	// defaultTee is the tee set played when a game doesn't choose one
+	defaultTee = "white"
+)
+
+// gameHandicaps turns handicap indexes into the course and playing handicaps
+// of a game, from the tee set each player plays from and the format's
+// allowance
+type gameHandicaps struct {
+	enabled   bool
+	allowance int                      // Percentage of course handicap received
+	courses   map[string]*playerCourse // Keyed by player ID
+}
+
+// courseHandicap returns a player's WHS course handicap for their tee set
+func (h *gameHandicaps) courseHandicap(playerID string, index float64) int {
+	return h.courses[playerID].courseHandicap(index)
+}
+
+// playingHandicap returns the strokes a player receives in the game, which is
+// their course handicap with the format's allowance applied
+func (h *gameHandicaps) playingHandicap(playerID string, index float64) int {
+	return int(math.Round(float64(h.courseHandicap(playerID, index)*h.allowance) / 100))
+}
+
+// applyToPlayer fills in a player's course and playing handicaps when the
+// game uses handicaps
+func (h *gameHandicaps) applyToPlayer(player *models.Player) {
+	if h.enabled {
+		player.CourseHandicap, player.PlayingHandicap = h.handicaps(player.ID, player.Handicap)
+	}
+}
+
+// applyToSummary fills in a player summary's course and playing handicaps
+// when the game uses handicaps
+func (h *gameHandicaps) applyToSummary(player *models.PlayerSummary) {
+	if h.enabled && player.Handicap != nil {
+		player.CourseHandicap, player.PlayingHandicap = h.handicaps(player.ID, *player.Handicap)
+	}
+}
+
+func (h *gameHandicaps) handicaps(playerID string, index float64) (*int, *int) {
+	course := h.courseHandicap(playerID, index)
+	playing := h.playingHandicap(playerID, index)
+	return &course, &playing
+}
+
+// playingHandicap returns the strokes a player summary receives, or zero when
+// the game doesn't use handicaps
+func playingHandicap(player models.PlayerSummary) int {
+	if player.PlayingHandicap == nil {
+		return 0
+	}
+	return *player.PlayingHandicap
+}
+
+// defaultHandicapAllowance returns the WHS recommended playing handicap
+// allowance, as a percentage, for a game's format
+func defaultHandicapAllowance(scoringFormat models.ScoringFormat, teamFormat *models.TeamFormat) int {
+	switch {
+	case teamFormat != nil && scoringFormat == models.ScoringMatchPlay:
+		return 90 // Four-ball match play
+	case teamFormat != nil:
+		return 85 // Four-ball stroke play
+	case scoringFormat == models.ScoringMatchPlay:
+		return 100
+	default:
+		return 95 // Individual stroke play and Stableford
+	}
+}
+
+// validateHandicapAllowance resolves a requested playing handicap allowance,
+// defaulting to the one recommended for the game's format
+func validateHandicapAllowance(allowance *int, scoringFormat models.ScoringFormat, teamFormat *models.TeamFormat) (int, error) {
+	if allowance == nil {
+		return defaultHandicapAllowance(scoringFormat, teamFormat), nil
+	}
+	if *allowance < 1 || *allowance > 100 {
+		return 0, errors.ValidationError("handicap_allowance", fmt.Sprintf("%d", *allowance), "must be between 1 and 100")
+	}
+	return *allowance, nil
+}
+
+// validateTee resolves a requested default tee for a game's players,
+// defaulting to the white tees
+func validateTee(tee string, tees []models.TeeSet) (string, error) {
+	if tee == "" {
+		tee = defaultTee
+	}
+	allowed := make([]interface{}, 0, len(tees))
+	seen := make(map[string]bool, len(tees))
+	for _, teeSet := range tees {
+		if teeSet.Name == tee {
+			return tee, nil
+		}
+		if !seen[teeSet.Name] {
+			seen[teeSet.Name] = true
+			allowed = append(allowed, teeSet.Name)
+		}
+	}
+	return "", errors.ValidationErrorWithAllowedValues("tee", tee, allowed)
+}
+
+// getGameHandicaps loads the allowance a game's handicaps use and the tee
+// set each player plays from
+func (s *sideBetStore) getGameHandicaps(gameID string) (*gameHandicaps, error) {
+	var handicaps gameHandicaps
+	err := s.db.QueryRow(
+		"SELECT handicap_enabled, handicap_allowance FROM games WHERE id = ?",
+		gameID,
+	).Scan(&handicaps.enabled, &handicaps.allowance)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Game", gameID)
+		}
+		return nil, err
+	}
+
+	handicaps.courses, err = s.getPlayerCourses(gameID)
+	if err != nil {
+		return nil, err
+	}
+	return &handicaps, nil
+}
+
+// getTeeSets loads a course's tee sets, men's then women's and longest first
+func (s *sideBetStore) getTeeSets(courseName string) ([]models.TeeSet, error) {
+	rows, err := s.db.Query(`
+		SELECT name, gender, course_rating, slope_rating, par
+		FROM tee_sets
+		WHERE course_name = ?
+		ORDER BY gender DESC, course_rating DESC
+	`, courseName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tees := []models.TeeSet{}
+	for rows.Next() {
+		var tee models.TeeSet
+		if err := rows.Scan(&tee.Name, &tee.Gender, &tee.CourseRating, &tee.SlopeRating, &tee.Par); err != nil {
+			return nil, err
+		}
+		tees = append(tees, tee)
+	}
+
+	return tees, rows.Err()
+}
@@ -0,0 +1,569 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// Limits on the points for a junk item
+const (
+	minJunkPoints = 1
+	maxJunkPoints = 10
+)
+
+// junkItems lists every junk item in display order
+var junkItems = []string{
+	models.JunkBirdie,
+	models.JunkEagle,
+	models.JunkChipIn,
+	models.JunkSandy,
+	models.JunkGreenie,
+	models.JunkBarkie,
+	models.JunkPolie,
+}
+
+// automaticJunk holds the items detected from scores
+var automaticJunk = map[string]bool{
+	models.JunkBirdie: true,
+	models.JunkEagle:  true,
+	models.JunkChipIn: true,
+}
+
+// JunkBet awards side points for birdies, sandies, greenies and the like.
+// Items that can be read from the scorecard are detected automatically and
+// the rest are tagged by hand. Each point collects the point value from
+// every other player.
+type JunkBet struct {
+	sideBetStore
+	pointValue float64
+}
+
+// NewJunkBet creates the junk side bet with the default dollar value of a point
+func NewJunkBet(db *sql.DB, pointValue float64) *JunkBet {
+	return &JunkBet{
+		sideBetStore: sideBetStore{db: db},
+		pointValue:   pointValue,
+	}
+}
+
+// Type returns the junk side bet type
+func (s *JunkBet) Type() models.SideBetType {
+	return models.SideBetJunk
+}
+
+// DefaultStake returns the configured dollar value of one point
+func (s *JunkBet) DefaultStake() models.BetStake {
+	value := s.pointValue
+	return models.BetStake{Value: &value}
+}
+
+// InitializePlayer returns an empty junk calculation
+func (s *JunkBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.JunkCalculationData{Items: map[string]int{}}, nil
+}
+
+// ApplyScore recalculates junk and reports what the player earned on the
+// scored hole
+func (s *JunkBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	standings, err := s.calculateJunk(gameID)
+	if err != nil {
+		return err
+	}
+	if err := s.saveSideBetCalculations(s.db, gameID, junkCalculations(standings.Players, false)); err != nil {
+		return err
+	}
+
+	earned := []models.JunkEvent{}
+	for _, event := range standings.Events {
+		if event.Hole == score.Hole && event.Player.ID == playerID {
+			earned = append(earned, event)
+		}
+	}
+	updates.Junk = earned
+
+	return nil
+}
+
+// Recalculate rebuilds the stored junk totals
+func (s *JunkBet) Recalculate(gameID string) error {
+	standings, err := s.calculateJunk(gameID)
+	if err != nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, junkCalculations(standings.Players, false))
+}
+
+// Standings returns each player's junk with every item earned
+func (s *JunkBet) Standings(gameID string) (interface{}, error) {
+	standings, err := s.calculateJunk(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// Finalize adds every player's junk points and money to the final results
+func (s *JunkBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateJunk(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	results.Junk = standings.Players
+	return junkCalculations(standings.Players, true), nil
+}
+
+// Settle returns each player's net junk winnings
+func (s *JunkBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	standings, err := s.calculateJunk(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make([]models.SettlementAmount, 0, len(standings.Players))
+	for _, result := range standings.Players {
+		amounts = append(amounts, models.SettlementAmount{Player: result.Player, Amount: result.Amount})
+	}
+	return amounts, nil
+}
+
+// Actions exposes the setup and event tagging endpoints
+func (s *JunkBet) Actions() []SideBetAction {
+	return []SideBetAction{
+		{
+			Method: http.MethodPost,
+			Path:   "/setup",
+			Handle: s.Setup,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/events",
+			Handle: s.TagEvents,
+		},
+	}
+}
+
+// Setup sets which junk items count, their points and the value of a point
+func (s *JunkBet) Setup(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetJunk)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status == models.GameStatusCompleted {
+		return nil, errors.New(errors.ErrGameAlreadyCompleted, "Cannot change junk setup after the game is completed")
+	}
+
+	var req models.JunkSetupRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	settings, err := s.getJunkSettings(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Items != nil {
+		if err := validateJunkTable(req.Items); err != nil {
+			return nil, err
+		}
+		settings.table = req.Items
+	}
+
+	if req.PointValue != nil {
+		if err := checkStakeAmount("point_value", *req.PointValue); err != nil {
+			return nil, err
+		}
+		settings.pointValue = *req.PointValue
+		if err := s.setBetStakeValue(gameID, models.SideBetJunk, settings.pointValue); err != nil {
+			return nil, fmt.Errorf("failed to save junk point value: %w", err)
+		}
+	}
+
+	items, err := json.Marshal(settings.table)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO junk_settings (game_id, items, point_value, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(game_id) DO UPDATE SET
+			items = excluded.items,
+			point_value = excluded.point_value,
+			updated_at = excluded.updated_at
+	`, gameID, string(items), settings.pointValue, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to save junk setup: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save junk calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// TagEvents records the hand-flagged junk a player earned on a hole,
+// replacing anything tagged for them on that hole before. An empty list
+// clears the hole.
+func (s *JunkBet) TagEvents(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetJunk)
+	if err != nil {
+		return nil, err
+	}
+
+	switch game.Status {
+	case models.GameStatusInProgress:
+	case models.GameStatusCompleted:
+		return nil, errors.New(errors.ErrGameAlreadyCompleted, "Cannot tag junk after the game is completed")
+	default:
+		return nil, errors.BusinessLogicError(
+			errors.ErrGameNotStarted,
+			"Cannot tag junk for game that is not in progress",
+			string(game.Status),
+			string(models.GameStatusInProgress),
+		)
+	}
+
+	var req models.JunkEventRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	if req.Hole < 1 || req.Hole > roundHoles {
+		return nil, errors.ValidationError("hole", fmt.Sprintf("%d", req.Hole), "must be between 1 and 18")
+	}
+
+	var count int
+	err = s.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM players p
+		WHERE p.id = ? AND p.game_id = ? AND `+sideBetParticipantSQL,
+		req.PlayerID, gameID, models.SideBetJunk,
+	).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.ValidationError("player_id", req.PlayerID, "must be playing this side bet")
+	}
+
+	settings, err := s.getJunkSettings(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range req.Items {
+		if err := s.validateJunkTag(gameID, req.PlayerID, req.Hole, item, settings.table); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM junk_events WHERE game_id = ? AND hole = ? AND player_id = ?", gameID, req.Hole, req.PlayerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear junk events: %w", err)
+	}
+
+	now := time.Now()
+	for _, item := range req.Items {
+		_, err = tx.Exec(`
+			INSERT OR IGNORE INTO junk_events (game_id, hole, player_id, item, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, gameID, req.Hole, req.PlayerID, item, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save junk event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to save junk events: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save junk calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// validateJunkTag checks that an item can be tagged by hand on a player's hole
+func (s *JunkBet) validateJunkTag(gameID, playerID string, hole int, item string, table map[string]int) error {
+	if automaticJunk[item] {
+		return errors.ValidationError("items", item, "detected automatically from scores")
+	}
+	if _, ok := table[item]; !ok {
+		allowed := []interface{}{}
+		for _, junk := range junkItems {
+			if _, counts := table[junk]; counts && !automaticJunk[junk] {
+				allowed = append(allowed, junk)
+			}
+		}
+		return errors.ValidationErrorWithAllowedValues("items", item, allowed)
+	}
+
+	if item == models.JunkGreenie {
+		course, err := s.getPlayerCourse(gameID, playerID)
+		if err != nil {
+			return err
+		}
+		if course.par(hole) != 3 {
+			return errors.ValidationError("items", item, "only on par 3 holes")
+		}
+	}
+
+	return nil
+}
+
+// junkSettings is a game's junk setup
+type junkSettings struct {
+	table      map[string]int
+	pointValue float64
+}
+
+// getJunkSettings loads the game's junk setup, or the defaults when it has
+// not been set up
+func (s *JunkBet) getJunkSettings(gameID string) (*junkSettings, error) {
+	stake, err := s.getBetStake(gameID, models.SideBetJunk, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	var items string
+	settings := &junkSettings{}
+
+	err = s.db.QueryRow(`
+		SELECT items, point_value
+		FROM junk_settings
+		WHERE game_id = ?
+	`, gameID).Scan(&items, &settings.pointValue)
+	if err == sql.ErrNoRows {
+		settings.table = models.DefaultJunkTable()
+		settings.pointValue = stake.value
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Games that predate stakes keep the point value from their setup
+	if stake.stored {
+		settings.pointValue = stake.value
+	}
+
+	if err := json.Unmarshal([]byte(items), &settings.table); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// calculateJunk collects every junk item earned, detecting birdies, eagles
+// and chip-ins from gross scores, and settles the points in dollars
+func (s *JunkBet) calculateJunk(gameID string) (*models.JunkStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetJunk)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getJunkSettings(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetJunk)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.PlayerSummary, len(players))
+	order := make(map[string]int, len(players))
+	results := make(map[string]*models.JunkResult, len(players))
+	for i, player := range players {
+		byID[player.ID] = player
+		order[player.ID] = i
+		results[player.ID] = &models.JunkResult{Player: player, Items: map[string]int{}}
+	}
+
+	standings := &models.JunkStandings{
+		BetType:    models.SideBetJunk,
+		Status:     game.Status,
+		Table:      settings.table,
+		PointValue: settings.pointValue,
+		Events:     []models.JunkEvent{},
+	}
+
+	// earn adds an item if it counts in this game
+	earn := func(hole int, playerID, item string, automatic bool) {
+		points, counts := settings.table[item]
+		result, ok := results[playerID]
+		if !counts || !ok {
+			return
+		}
+		result.Items[item]++
+		result.Points += points
+		standings.Events = append(standings.Events, models.JunkEvent{
+			Hole:      hole,
+			Player:    byID[playerID],
+			Item:      item,
+			Points:    points,
+			Automatic: automatic,
+		})
+	}
+
+	scoreRows, err := s.db.Query(`
+		SELECT s.player_id, s.hole, s.strokes, s.putts, s.par
+		FROM scores s
+		JOIN players p ON p.id = s.player_id
+		WHERE s.game_id = ? AND `+sideBetParticipantSQL+`
+	`, gameID, models.SideBetJunk)
+	if err != nil {
+		return nil, err
+	}
+	defer scoreRows.Close()
+
+	for scoreRows.Next() {
+		var playerID string
+		var hole, strokes, putts, par int
+		if err := scoreRows.Scan(&playerID, &hole, &strokes, &putts, &par); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case strokes <= par-2:
+			earn(hole, playerID, models.JunkEagle, true)
+		case strokes == par-1:
+			earn(hole, playerID, models.JunkBirdie, true)
+		}
+		if putts == 0 && strokes > 1 {
+			earn(hole, playerID, models.JunkChipIn, true)
+		}
+	}
+	if err := scoreRows.Err(); err != nil {
+		return nil, err
+	}
+
+	eventRows, err := s.db.Query(`
+		SELECT hole, player_id, item
+		FROM junk_events
+		WHERE game_id = ?
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer eventRows.Close()
+
+	for eventRows.Next() {
+		var hole int
+		var playerID, item string
+		if err := eventRows.Scan(&hole, &playerID, &item); err != nil {
+			return nil, err
+		}
+		earn(hole, playerID, item, false)
+	}
+	if err := eventRows.Err(); err != nil {
+		return nil, err
+	}
+
+	itemOrder := make(map[string]int, len(junkItems))
+	for i, item := range junkItems {
+		itemOrder[item] = i
+	}
+	sort.SliceStable(standings.Events, func(i, j int) bool {
+		a, b := standings.Events[i], standings.Events[j]
+		if a.Hole != b.Hole {
+			return a.Hole < b.Hole
+		}
+		if a.Player.ID != b.Player.ID {
+			return order[a.Player.ID] < order[b.Player.ID]
+		}
+		return itemOrder[a.Item] < itemOrder[b.Item]
+	})
+
+	// Each point collects the point value from every other player
+	total := 0
+	for _, result := range results {
+		total += result.Points
+	}
+
+	standings.Players = make([]models.JunkResult, 0, len(players))
+	for _, player := range players {
+		result := results[player.ID]
+		result.Amount = roundCurrency(float64(result.Points*len(players)-total) * settings.pointValue)
+		standings.Players = append(standings.Players, *result)
+	}
+	sort.SliceStable(standings.Players, func(i, j int) bool {
+		return standings.Players[i].Points > standings.Players[j].Points
+	})
+	for i := range standings.Players {
+		if i > 0 && standings.Players[i].Points == standings.Players[i-1].Points {
+			standings.Players[i].Position = standings.Players[i-1].Position
+			continue
+		}
+		standings.Players[i].Position = i + 1
+	}
+
+	return standings, nil
+}
+
+// junkCalculations builds the stored junk totals
+func junkCalculations(results []models.JunkResult, final bool) []sideBetCalculation {
+	calculations := make([]sideBetCalculation, 0, len(results))
+	for _, result := range results {
+		data := models.JunkCalculationData{
+			Items:  result.Items,
+			Points: result.Points,
+			Amount: result.Amount,
+		}
+		isWinner := final && result.Position == 1 && result.Points > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: result.Player.ID,
+			betType:  models.SideBetJunk,
+			data:     data,
+			position: result.Position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+	return calculations
+}
+
+// validateJunkTable checks a custom junk table
+func validateJunkTable(table map[string]int) error {
+	if len(table) == 0 {
+		return errors.ValidationError("items", "{}", "must include at least one item")
+	}
+
+	known := make(map[string]bool, len(junkItems))
+	allowed := make([]interface{}, 0, len(junkItems))
+	for _, item := range junkItems {
+		known[item] = true
+		allowed = append(allowed, item)
+	}
+
+	for item, points := range table {
+		if !known[item] {
+			return errors.ValidationErrorWithAllowedValues("items", item, allowed)
+		}
+		if points < minJunkPoints || points > maxJunkPoints {
+			return errors.ValidationError("items."+item, fmt.Sprintf("%d", points), fmt.Sprintf("must be between %d and %d", minJunkPoints, maxJunkPoints))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import "sync"
+
+// gameLocks hands out a read/write lock per game. Locks are dropped once no
+// one holds or is waiting on them.
+type gameLocks struct {
+	mu    sync.Mutex
+	locks map[string]*gameLock
+}
+
+type gameLock struct {
+	sync.RWMutex
+	refs int
+}
+
+func newGameLocks() *gameLocks {
+	return &gameLocks{locks: make(map[string]*gameLock)}
+}
+
+// lock takes a game's lock for writing and returns the function that releases it
+func (l *gameLocks) lock(gameID string) func() {
+	lock := l.acquire(gameID)
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		l.release(gameID)
+	}
+}
+
+// rlock takes a game's lock for reading and returns the function that releases it
+func (l *gameLocks) rlock(gameID string) func() {
+	lock := l.acquire(gameID)
+	lock.RLock()
+	return func() {
+		lock.RUnlock()
+		l.release(gameID)
+	}
+}
+
+func (l *gameLocks) acquire(gameID string) *gameLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[gameID]
+	if !ok {
+		lock = &gameLock{}
+		l.locks[gameID] = lock
+	}
+	lock.refs++
+	return lock
+}
+
+func (l *gameLocks) release(gameID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock := l.locks[gameID]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, gameID)
+	}
+}
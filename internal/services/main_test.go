@@ -0,0 +1,33 @@
+package services
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golf-gamez/internal/database"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	os.Exit(m.Run())
+}
+
+// newTestDB opens a migrated database that is removed after the test
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := database.Connect(filepath.Join(t.TempDir(), "golf_gamez.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
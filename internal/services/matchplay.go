@@ -0,0 +1,257 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// Match play sides and hole results
+const (
+	matchSideA  = "a"
+	matchSideB  = "b"
+	matchHalved = "halved"
+)
+
+// Players needed for each match play format
+const (
+	matchSinglesPlayers  = 2
+	matchFourBallPlayers = 4
+)
+
+// checkMatchPlayPlayers reports whether a game has a playable match
+func checkMatchPlayPlayers(players int) error {
+	if players == matchSinglesPlayers || players == matchFourBallPlayers {
+		return nil
+	}
+	return errors.NewWithDetails(errors.ErrInvalidGameState, "Match play requires 2 players for singles or 4 for four-ball", map[string]interface{}{
+		"players":         players,
+		"allowed_players": []int{matchSinglesPlayers, matchFourBallPlayers},
+	})
+}
+
+// calculateMatchPlay plays the match hole by hole. Singles is the first
+// player against the second; four-ball is the first two players against the
+// last two with each side's best ball counting. Holes are decided in order,
+// so the match stops at the first incomplete hole or once it is closed out.
+// Returns nil when the game does not have a playable number of players.
+func (s *ScoreService) calculateMatchPlay(gameID string, scoring *gameScoring) (*models.MatchPlayStatus, error) {
+	rounds, err := s.getPlayerRounds(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if checkMatchPlayPlayers(len(rounds)) != nil {
+		return nil, nil
+	}
+
+	match := &models.MatchPlayStatus{
+		Format: models.MatchSingles,
+		Holes:  []models.MatchHoleResult{},
+	}
+	sides := [2][]*playerRound{rounds[:1], rounds[1:]}
+	if len(rounds) == matchFourBallPlayers {
+		match.Format = models.MatchFourBall
+		sides = [2][]*playerRound{rounds[:2], rounds[2:]}
+	}
+
+	// Strokes are given off the lowest handicap in the match
+	strokes := make(map[string]int, len(rounds))
+	if scoring.HandicapEnabled {
+		low := math.MaxInt
+		for _, round := range rounds {
+			if h := roundedHandicap(&round.handicap); h < low {
+				low = h
+			}
+		}
+		for _, round := range rounds {
+			strokes[round.player.ID] = roundedHandicap(&round.handicap) - low
+		}
+		match.Strokes = strokes
+	}
+
+	margin := 0
+	leader := func() *string {
+		switch {
+		case margin > 0:
+			side := matchSideA
+			return &side
+		case margin < 0:
+			side := matchSideB
+			return &side
+		default:
+			return nil
+		}
+	}
+
+	for hole := 1; hole <= roundHoles && !match.Complete; hole++ {
+		best := [2]int{math.MaxInt, math.MaxInt}
+		complete := true
+		for i, side := range sides {
+			for _, round := range side {
+				score, ok := round.holes[hole]
+				if !ok {
+					complete = false
+					break
+				}
+				net := score.strokes - allocatedStrokes(strokes[round.player.ID], scoring.ranking(round.player.ID, hole))
+				if net < best[i] {
+					best[i] = net
+				}
+			}
+		}
+		if !complete {
+			break
+		}
+
+		result := models.MatchHoleResult{Hole: hole, SideA: best[0], SideB: best[1], Winner: matchHalved}
+		switch {
+		case best[0] < best[1]:
+			result.Winner = matchSideA
+			margin++
+		case best[1] < best[0]:
+			result.Winner = matchSideB
+			margin--
+		}
+
+		match.Thru = hole
+		remaining := roundHoles - hole
+		match.Complete = remaining == 0 || abs(margin) > remaining
+		match.ClosedOut = match.Complete && remaining > 0
+		match.Dormie = !match.Complete && margin != 0 && abs(margin) == remaining
+
+		result.Status = matchStatus(margin, remaining, match.Complete)
+		result.Leader = leader()
+		match.Holes = append(match.Holes, result)
+	}
+
+	match.Status = matchStatus(margin, roundHoles-match.Thru, match.Complete)
+	match.Leader = leader()
+
+	for i, side := range sides {
+		name := matchSideA
+		sideMargin := margin
+		if i == 1 {
+			name = matchSideB
+			sideMargin = -margin
+		}
+
+		matchSide := models.MatchSide{
+			Side:   name,
+			Status: matchSideStatus(sideMargin, roundHoles-match.Thru, match.Complete),
+		}
+		names := make([]string, 0, len(side))
+		for _, round := range side {
+			matchSide.Players = append(matchSide.Players, round.player)
+			names = append(names, round.player.Name)
+		}
+		matchSide.Name = strings.Join(names, " & ")
+		match.Sides = append(match.Sides, matchSide)
+	}
+
+	return match, nil
+}
+
+// getMatchPlayLeaderboard lists the leading side first with each player
+// showing their side's match status. Until the match can be played the
+// leaderboard falls back to stroke play.
+func (s *ScoreService) getMatchPlayLeaderboard(gameID string, scoring *gameScoring) ([]models.LeaderboardEntry, error) {
+	match, err := s.calculateMatchPlay(gameID, scoring)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return s.getStrokePlayLeaderboard(gameID)
+	}
+
+	rounds, err := s.getPlayerRounds(gameID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*playerRound, len(rounds))
+	for _, round := range rounds {
+		byID[round.player.ID] = round
+	}
+
+	sides := match.Sides
+	if match.Leader != nil && *match.Leader == matchSideB {
+		sides = []models.MatchSide{match.Sides[1], match.Sides[0]}
+	}
+
+	var entries []models.LeaderboardEntry
+	for i, side := range sides {
+		position := 1
+		if i > 0 && match.Leader != nil {
+			position = 2
+		}
+		for _, player := range side.Players {
+			round := byID[player.ID]
+			entries = append(entries, models.LeaderboardEntry{
+				Position:       position,
+				Player:         player,
+				Score:          side.Status,
+				HolesCompleted: round.holesCompleted,
+				TotalPutts:     round.totalPutts,
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// matchPlayWinner returns the winning side of a finished match, or nil for
+// a halved or unfinished match
+func matchPlayWinner(match *models.MatchPlayStatus) *models.Winner {
+	if !match.Complete || match.Leader == nil {
+		return nil
+	}
+
+	for _, side := range match.Sides {
+		if side.Side != *match.Leader {
+			continue
+		}
+		winner := &models.Winner{PlayerID: side.Players[0].ID, Score: match.Status}
+		for _, partner := range side.Players[1:] {
+			winner.PartnerIDs = append(winner.PartnerIDs, partner.ID)
+		}
+		return winner
+	}
+
+	return nil
+}
+
+// matchStatus describes a match from the leader's view: "AS" when all
+// square, "2 UP" while in progress or won on the last hole, and "3&2" when
+// closed out with holes remaining
+func matchStatus(margin, remaining int, complete bool) string {
+	switch {
+	case margin == 0:
+		return "AS"
+	case complete && remaining > 0:
+		return fmt.Sprintf("%d&%d", abs(margin), remaining)
+	default:
+		return fmt.Sprintf("%d UP", abs(margin))
+	}
+}
+
+// matchSideStatus describes a match from one side's view, such as "2 DN"
+// while trailing or "Won 3&2" once the match is over. The losing side sees
+// the same result as the winner, so a match lost on the last hole is
+// "Lost 1 UP".
+func matchSideStatus(margin, remaining int, complete bool) string {
+	status := matchStatus(margin, remaining, complete)
+	switch {
+	case complete && margin == 0:
+		return "Halved"
+	case complete && margin > 0:
+		return "Won " + status
+	case complete:
+		return "Lost " + status
+	case margin < 0:
+		return fmt.Sprintf("%d DN", -margin)
+	default:
+		return status
+	}
+}
@@ -0,0 +1,32 @@
+package services
+
+import "testing"
+
+func TestMatchSideStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		margin    int
+		remaining int
+		complete  bool
+		want      string
+	}{
+		{"all square", 0, 10, false, "AS"},
+		{"leading", 2, 10, false, "2 UP"},
+		{"trailing", -2, 10, false, "2 DN"},
+		{"dormie", 3, 3, false, "3 UP"},
+		{"won early", 3, 2, true, "Won 3&2"},
+		{"lost early", -3, 2, true, "Lost 3&2"},
+		{"won on the last", 1, 0, true, "Won 1 UP"},
+		{"lost on the last", -1, 0, true, "Lost 1 UP"},
+		{"lost by two on the last", -2, 0, true, "Lost 2 UP"},
+		{"halved", 0, 0, true, "Halved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchSideStatus(tt.margin, tt.remaining, tt.complete); got != tt.want {
+				t.Errorf("matchSideStatus(%d, %d, %t) = %q, want %q", tt.margin, tt.remaining, tt.complete, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"database/sql"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// validateMaxScore resolves a requested max score rule. Net double bogey, the
+// cap used for handicap posting, is the default.
+func validateMaxScore(rule models.MaxScoreRule) (models.MaxScoreRule, error) {
+	switch rule {
+	case "":
+		return models.MaxScoreNetDoubleBogey, nil
+	case models.MaxScoreNetDoubleBogey, models.MaxScoreDoublePar, models.MaxScoreNone:
+		return rule, nil
+	default:
+		return "", errors.ValidationErrorWithAllowedValues("max_score", string(rule), []interface{}{
+			models.MaxScoreNetDoubleBogey,
+			models.MaxScoreDoublePar,
+			models.MaxScoreNone,
+		})
+	}
+}
+
+// maxScoreCap caps a player's holes for adjusted gross scores
+type maxScoreCap struct {
+	rule   models.MaxScoreRule
+	course *playerCourse
+	index  float64 // Handicap index
+}
+
+// adjustedStrokes returns the strokes a hole counts for in adjusted gross
+// scores. Net double bogey gives the strokes received off the full course
+// handicap, as handicap posting does, whether or not the game itself uses
+// handicaps or an allowance.
+func (c maxScoreCap) adjustedStrokes(hole, strokes int) int {
+	par := c.course.par(hole)
+	limit := strokes
+	switch c.rule {
+	case models.MaxScoreNetDoubleBogey:
+		limit = par + 2 + allocatedStrokes(c.course.courseHandicap(c.index), c.course.ranking(hole))
+	case models.MaxScoreDoublePar:
+		limit = 2 * par
+	}
+	if strokes > limit {
+		return limit
+	}
+	return strokes
+}
+
+// getMaxScoreCap loads a game's max score rule with the player's course and
+// handicap index
+func (s *ScoreService) getMaxScoreCap(gameID, playerID string) (*maxScoreCap, error) {
+	maxScore := maxScoreCap{}
+	err := s.db.QueryRow(`
+		SELECT g.max_score, p.handicap
+		FROM players p
+		JOIN games g ON g.id = p.game_id
+		WHERE p.id = ? AND p.game_id = ?
+	`, playerID, gameID).Scan(&maxScore.rule, &maxScore.index)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Player", playerID)
+		}
+		return nil, err
+	}
+
+	maxScore.course, err = (&sideBetStore{db: s.db}).getPlayerCourse(gameID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	return &maxScore, nil
+}
@@ -0,0 +1,791 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/auth"
+	"golf-gamez/pkg/errors"
+)
+
+const (
+	// nassauPressTrigger is how many holes down a side is when a press starts automatically
+	nassauPressTrigger = 2
+
+	nassauSideA  = "a"
+	nassauSideB  = "b"
+	nassauHalved = "halved"
+
+	nassauKindMatch       = "match"
+	nassauKindAutoPress   = "auto_press"
+	nassauKindManualPress = "manual_press"
+)
+
+// nassauSegment is one of the three Nassau matches
+type nassauSegment struct {
+	name       string
+	start, end int
+}
+
+var nassauSegments = []nassauSegment{
+	{name: models.NassauFront, start: 1, end: 9},
+	{name: models.NassauBack, start: 10, end: 18},
+	{name: models.NassauOverall, start: 1, end: 18},
+}
+
+// NassauBet plays three matches between two sides, a player or two-player
+// team each: the front nine, the back nine and the overall eighteen.
+type NassauBet struct {
+	sideBetStore
+	stake float64
+}
+
+// NewNassauBet creates the Nassau side bet with the default stake per match
+func NewNassauBet(db *sql.DB, stake float64) *NassauBet {
+	return &NassauBet{
+		sideBetStore: sideBetStore{db: db},
+		stake:        stake,
+	}
+}
+
+// Type returns the Nassau side bet type
+func (s *NassauBet) Type() models.SideBetType {
+	return models.SideBetNassau
+}
+
+// DefaultStake returns the configured stake per match
+func (s *NassauBet) DefaultStake() models.BetStake {
+	value := s.stake
+	return models.BetStake{Value: &value}
+}
+
+// InitializePlayer returns an empty Nassau calculation
+func (s *NassauBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.NassauCalculationData{}, nil
+}
+
+// ApplyScore recalculates the matches and reports their live status
+func (s *NassauBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	standings, err := s.calculateNassau(gameID)
+	if err != nil || standings == nil {
+		return err
+	}
+	if err := s.saveSideBetCalculations(s.db, gameID, nassauCalculations(standings, false)); err != nil {
+		return err
+	}
+
+	update := &models.NassauUpdate{Thru: standings.Thru}
+	for _, bet := range standings.Bets {
+		if bet.Kind == nassauKindMatch {
+			switch bet.Segment {
+			case models.NassauFront:
+				update.Front = bet.Status
+			case models.NassauBack:
+				update.Back = bet.Status
+			case models.NassauOverall:
+				update.Overall = bet.Status
+			}
+		}
+		if bet.Kind == nassauKindAutoPress && bet.StartHole == score.Hole+1 && standings.Thru == score.Hole {
+			update.NewPress = append(update.NewPress, bet.ID)
+		}
+	}
+	updates.Nassau = update
+
+	return nil
+}
+
+// Recalculate rebuilds the stored Nassau results
+func (s *NassauBet) Recalculate(gameID string) error {
+	standings, err := s.calculateNassau(gameID)
+	if err != nil || standings == nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, nassauCalculations(standings, false))
+}
+
+// Standings returns every Nassau match and press with its live status
+func (s *NassauBet) Standings(gameID string) (interface{}, error) {
+	if _, err := s.getGameForSideBet(gameID, models.SideBetNassau); err != nil {
+		return nil, err
+	}
+
+	standings, err := s.calculateNassau(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if standings == nil {
+		return nil, nassauNotSetUpError()
+	}
+
+	return standings, nil
+}
+
+// Finalize adds the Nassau settlement to the final results. Matches and
+// presses that were not finished are not paid out.
+func (s *NassauBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateNassau(gameID)
+	if err != nil || standings == nil {
+		return nil, err
+	}
+
+	results.Nassau = &models.NassauSettlement{
+		Bets:       standings.Bets,
+		Settlement: standings.Settlement,
+	}
+	return nassauCalculations(standings, true), nil
+}
+
+// Settle returns each player's winnings from finished matches and presses
+func (s *NassauBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	standings, err := s.calculateNassau(gameID)
+	if err != nil || standings == nil {
+		return []models.SettlementAmount{}, err
+	}
+
+	amounts := make([]models.SettlementAmount, 0, len(standings.Settlement))
+	for _, payout := range standings.Settlement {
+		amounts = append(amounts, models.SettlementAmount{Player: payout.Player, Amount: payout.Amount})
+	}
+	return amounts, nil
+}
+
+// Actions exposes the setup and manual press endpoints
+func (s *NassauBet) Actions() []SideBetAction {
+	return []SideBetAction{
+		{
+			Method: http.MethodPost,
+			Path:   "/setup",
+			Handle: s.Setup,
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/press",
+			Status: http.StatusCreated,
+			Handle: s.Press,
+		},
+	}
+}
+
+// Setup sets the two sides, the stake per match and whether presses start
+// automatically
+func (s *NassauBet) Setup(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetNassau)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status == models.GameStatusCompleted {
+		return nil, errors.New(errors.ErrGameAlreadyCompleted, "Cannot change Nassau setup after the game is completed")
+	}
+
+	var req models.NassauSetupRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetNassau)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateNassauSides(req.Sides, players); err != nil {
+		return nil, err
+	}
+
+	betStake, err := s.getBetStake(gameID, models.SideBetNassau, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+	stake := betStake.value
+	if req.Stake != nil {
+		if err := checkStakeAmount("stake", *req.Stake); err != nil {
+			return nil, err
+		}
+		stake = *req.Stake
+		if err := s.setBetStakeValue(gameID, models.SideBetNassau, stake); err != nil {
+			return nil, fmt.Errorf("failed to save nassau stake: %w", err)
+		}
+	}
+
+	autoPress := true
+	if req.AutoPress != nil {
+		autoPress = *req.AutoPress
+	}
+
+	sideA, err := json.Marshal(req.Sides[0])
+	if err != nil {
+		return nil, err
+	}
+	sideB, err := json.Marshal(req.Sides[1])
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO nassau_settings (game_id, side_a, side_b, stake, auto_press, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(game_id) DO UPDATE SET
+			side_a = excluded.side_a,
+			side_b = excluded.side_b,
+			stake = excluded.stake,
+			auto_press = excluded.auto_press,
+			updated_at = excluded.updated_at
+	`, gameID, string(sideA), string(sideB), stake, autoPress, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to save nassau setup: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save nassau calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// Press starts a manual press for the side that is down in a segment's
+// most recent bet. The press begins on the next hole to be played.
+func (s *NassauBet) Press(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetNassau)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != models.GameStatusInProgress {
+		return nil, errors.BusinessLogicError(
+			errors.ErrInvalidGameState,
+			"Presses can only be made during the round",
+			string(game.Status),
+			string(models.GameStatusInProgress),
+		)
+	}
+
+	var req models.NassauPressRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	var segment *nassauSegment
+	for i := range nassauSegments {
+		if nassauSegments[i].name == req.Segment {
+			segment = &nassauSegments[i]
+		}
+	}
+	if segment == nil {
+		return nil, errors.ValidationErrorWithAllowedValues(
+			"segment",
+			req.Segment,
+			[]interface{}{models.NassauFront, models.NassauBack, models.NassauOverall},
+		)
+	}
+
+	standings, err := s.calculateNassau(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if standings == nil {
+		return nil, nassauNotSetUpError()
+	}
+
+	side := ""
+	for _, nassauSide := range standings.Sides {
+		for _, player := range nassauSide.Players {
+			if player.ID == req.PlayerID {
+				side = nassauSide.Side
+			}
+		}
+	}
+	if side == "" {
+		return nil, errors.ValidationError("player_id", req.PlayerID, "must be a player in the Nassau")
+	}
+
+	startHole := standings.Thru + 1
+	if startHole < segment.start || startHole > segment.end {
+		return nil, errors.NewWithDetails(errors.ErrValidation, "No holes remain to press in this segment", map[string]interface{}{
+			"segment":   segment.name,
+			"next_hole": startHole,
+		})
+	}
+
+	// The side calling the press must be down in the segment's latest bet
+	var latest *models.NassauMatch
+	for i, bet := range standings.Bets {
+		if bet.Segment != segment.name {
+			continue
+		}
+		if bet.StartHole == startHole {
+			return nil, errors.NewWithDetails(errors.ErrValidation, "A press already starts on this hole", map[string]interface{}{
+				"segment":    segment.name,
+				"start_hole": startHole,
+			})
+		}
+		if latest == nil || bet.StartHole > latest.StartHole {
+			latest = &standings.Bets[i]
+		}
+	}
+	if latest == nil || latest.Leader == nil || *latest.Leader == side {
+		return nil, errors.NewWithDetails(errors.ErrValidation, "Only the side that is down can press", map[string]interface{}{
+			"segment": segment.name,
+			"side":    side,
+		})
+	}
+
+	pressID, err := auth.GeneratePressID()
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO nassau_presses (id, game_id, segment, side, start_hole)
+		VALUES (?, ?, ?, ?, ?)
+	`, pressID, gameID, segment.name, side, startHole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save press: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save nassau calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// nassauSettings is the stored or default configuration of a game's Nassau
+type nassauSettings struct {
+	sides     [2][]string
+	stake     float64
+	autoPress bool
+}
+
+// nassauPress is a stored manual press
+type nassauPress struct {
+	segment   string
+	side      string
+	startHole int
+}
+
+// calculateNassau plays out every match and press from the recorded
+// scores. Holes count once every player in the Nassau has scored them, in
+// hole order. Returns nil standings when the sides have not been set up.
+func (s *NassauBet) calculateNassau(gameID string) (*models.NassauStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetNassau)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetNassau)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getNassauSettings(gameID, players)
+	if err != nil || settings == nil {
+		return nil, err
+	}
+
+	presses, err := s.getNassauPresses(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.getPlayerCourses(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.PlayerSummary, len(players))
+	for _, player := range players {
+		byID[player.ID] = player
+	}
+
+	standings := &models.NassauStandings{
+		BetType:         models.SideBetNassau,
+		Status:          game.Status,
+		HandicapEnabled: game.HandicapEnabled,
+		Stake:           settings.stake,
+		AutoPress:       settings.autoPress,
+		Holes:           []models.NassauHoleResult{},
+	}
+
+	var matchPlayers []string
+	for i, ids := range settings.sides {
+		side := models.NassauSide{Side: nassauSideName(i)}
+		names := make([]string, 0, len(ids))
+		for _, id := range ids {
+			side.Players = append(side.Players, byID[id])
+			names = append(names, byID[id].Name)
+			matchPlayers = append(matchPlayers, id)
+		}
+		side.Name = strings.Join(names, " & ")
+		standings.Sides = append(standings.Sides, side)
+	}
+
+	// Strokes are given off the lowest handicap in the match
+	strokes := make(map[string]int, len(matchPlayers))
+	if game.HandicapEnabled {
+		strokes = strokesOffLow(matchPlayers, byID)
+		standings.Strokes = strokes
+	}
+
+	scores := make(map[string]map[int]int, len(matchPlayers))
+	for _, id := range matchPlayers {
+		holeScores, err := s.getPlayerHoleScores(id)
+		if err != nil {
+			return nil, err
+		}
+		scores[id] = make(map[int]int, len(holeScores))
+		for _, score := range holeScores {
+			scores[id][score.Hole] = score.Strokes
+		}
+	}
+
+	bets := make([]*models.NassauMatch, 0, len(nassauSegments))
+	for _, segment := range nassauSegments {
+		bets = append(bets, newNassauMatch(segment, segment.start, nassauKindMatch, settings.stake))
+	}
+	for _, press := range presses {
+		for _, segment := range nassauSegments {
+			if segment.name == press.segment {
+				bets = append(bets, newNassauMatch(segment, press.startHole, nassauKindManualPress, settings.stake))
+			}
+		}
+	}
+
+	for hole := 1; hole <= roundHoles; hole++ {
+		best := [2]int{math.MaxInt, math.MaxInt}
+		complete := true
+		for i, ids := range settings.sides {
+			for _, id := range ids {
+				strokesTaken, ok := scores[id][hole]
+				if !ok {
+					complete = false
+					break
+				}
+				net := strokesTaken - allocatedStrokes(strokes[id], courses[id].ranking(hole))
+				if net < best[i] {
+					best[i] = net
+				}
+			}
+		}
+		if !complete {
+			break
+		}
+		standings.Thru = hole
+
+		result := models.NassauHoleResult{Hole: hole, SideA: best[0], SideB: best[1], Winner: nassauHalved}
+		swing := 0
+		switch {
+		case best[0] < best[1]:
+			result.Winner = nassauSideA
+			swing = 1
+		case best[1] < best[0]:
+			result.Winner = nassauSideB
+			swing = -1
+		}
+		standings.Holes = append(standings.Holes, result)
+
+		for _, bet := range bets {
+			if hole < bet.StartHole || hole > bet.EndHole || bet.Complete {
+				continue
+			}
+			bet.HolesPlayed++
+			bet.Margin += swing
+			remaining := bet.EndHole - hole
+			bet.Complete = remaining == 0 || abs(bet.Margin) > remaining
+		}
+
+		// A side going two down in a segment's latest bet starts a new press
+		if !settings.autoPress {
+			continue
+		}
+		for _, segment := range nassauSegments {
+			if hole >= segment.end {
+				continue
+			}
+			var latest *models.NassauMatch
+			pressed := false
+			for _, bet := range bets {
+				if bet.Segment != segment.name {
+					continue
+				}
+				if bet.StartHole == hole+1 {
+					pressed = true
+				}
+				if bet.StartHole <= hole && (latest == nil || bet.StartHole > latest.StartHole) {
+					latest = bet
+				}
+			}
+			if !pressed && latest != nil && !latest.Complete && abs(latest.Margin) == nassauPressTrigger {
+				bets = append(bets, newNassauMatch(segment, hole+1, nassauKindAutoPress, settings.stake))
+			}
+		}
+	}
+
+	segmentOrder := map[string]int{models.NassauFront: 0, models.NassauBack: 1, models.NassauOverall: 2}
+	sort.SliceStable(bets, func(i, j int) bool {
+		if bets[i].Segment != bets[j].Segment {
+			return segmentOrder[bets[i].Segment] < segmentOrder[bets[j].Segment]
+		}
+		return bets[i].StartHole < bets[j].StartHole
+	})
+
+	amounts := make(map[string]float64, len(matchPlayers))
+	for _, bet := range bets {
+		describeNassauMatch(bet, standings.Thru)
+		standings.Bets = append(standings.Bets, *bet)
+
+		if bet.Winner == nil {
+			continue
+		}
+		for i, ids := range settings.sides {
+			for _, id := range ids {
+				if nassauSideName(i) == *bet.Winner {
+					amounts[id] += bet.Stake
+				} else {
+					amounts[id] -= bet.Stake
+				}
+			}
+		}
+	}
+
+	for _, id := range matchPlayers {
+		standings.Settlement = append(standings.Settlement, models.NassauPayout{
+			Player: byID[id],
+			Amount: roundCurrency(amounts[id]),
+		})
+	}
+
+	return standings, nil
+}
+
+// newNassauMatch creates a match or press running from startHole to the end of the segment
+func newNassauMatch(segment nassauSegment, startHole int, kind string, stake float64) *models.NassauMatch {
+	id := segment.name
+	if kind != nassauKindMatch {
+		id = fmt.Sprintf("%s-press-%d", segment.name, startHole)
+	}
+	return &models.NassauMatch{
+		ID:        id,
+		Segment:   segment.name,
+		Kind:      kind,
+		StartHole: startHole,
+		EndHole:   segment.end,
+		Stake:     stake,
+	}
+}
+
+// describeNassauMatch sets the leader, winner and status text of a bet,
+// such as "1 up thru 7", "All square thru 4", "3&2" or "Halved"
+func describeNassauMatch(bet *models.NassauMatch, thru int) {
+	if bet.Margin != 0 {
+		leader := nassauSideA
+		if bet.Margin < 0 {
+			leader = nassauSideB
+		}
+		bet.Leader = &leader
+	}
+
+	lead := abs(bet.Margin)
+	remaining := bet.EndHole - bet.StartHole + 1 - bet.HolesPlayed
+
+	switch {
+	case bet.HolesPlayed == 0:
+		bet.Status = "Not started"
+	case bet.Complete && lead == 0:
+		bet.Status = "Halved"
+	case bet.Complete && remaining > 0:
+		bet.Status = fmt.Sprintf("%d&%d", lead, remaining)
+	case bet.Complete:
+		bet.Status = fmt.Sprintf("%d up", lead)
+	case lead == 0:
+		bet.Status = fmt.Sprintf("All square thru %d", thru)
+	default:
+		bet.Status = fmt.Sprintf("%d up thru %d", lead, thru)
+	}
+
+	if bet.Complete && bet.Leader != nil {
+		bet.Winner = bet.Leader
+	}
+}
+
+// getNassauSettings loads the game's Nassau setup. Without one, two players
+// play each other and four players play first two against last two in tee
+// order; any other field returns nil until sides are set up.
+func (s *NassauBet) getNassauSettings(gameID string, players []models.PlayerSummary) (*nassauSettings, error) {
+	stake, err := s.getBetStake(gameID, models.SideBetNassau, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	var sideA, sideB string
+	settings := &nassauSettings{}
+
+	err = s.db.QueryRow(`
+		SELECT side_a, side_b, stake, auto_press
+		FROM nassau_settings
+		WHERE game_id = ?
+	`, gameID).Scan(&sideA, &sideB, &settings.stake, &settings.autoPress)
+	if err == sql.ErrNoRows {
+		settings.stake = stake.value
+		settings.autoPress = true
+		switch len(players) {
+		case 2:
+			settings.sides = [2][]string{{players[0].ID}, {players[1].ID}}
+		case 4:
+			settings.sides = [2][]string{{players[0].ID, players[1].ID}, {players[2].ID, players[3].ID}}
+		default:
+			return nil, nil
+		}
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Games that predate stakes keep the stake from their setup
+	if stake.stored {
+		settings.stake = stake.value
+	}
+
+	if err := json.Unmarshal([]byte(sideA), &settings.sides[0]); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(sideB), &settings.sides[1]); err != nil {
+		return nil, err
+	}
+
+	// Sides referring to removed players need setting up again
+	if validateNassauSides(settings.sides[:], players) != nil {
+		return nil, nil
+	}
+
+	return settings, nil
+}
+
+// getNassauPresses loads a game's manual presses
+func (s *NassauBet) getNassauPresses(gameID string) ([]nassauPress, error) {
+	rows, err := s.db.Query(`
+		SELECT segment, side, start_hole
+		FROM nassau_presses
+		WHERE game_id = ?
+		ORDER BY start_hole, created_at
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presses []nassauPress
+	for rows.Next() {
+		var press nassauPress
+		if err := rows.Scan(&press.segment, &press.side, &press.startHole); err != nil {
+			return nil, err
+		}
+		presses = append(presses, press)
+	}
+
+	return presses, rows.Err()
+}
+
+// nassauCalculations builds each player's stored Nassau winnings
+func nassauCalculations(standings *models.NassauStandings, final bool) []sideBetCalculation {
+	sides := make(map[string]string)
+	for _, side := range standings.Sides {
+		for _, player := range side.Players {
+			sides[player.ID] = side.Side
+		}
+	}
+
+	payouts := append([]models.NassauPayout{}, standings.Settlement...)
+	sort.SliceStable(payouts, func(i, j int) bool {
+		return payouts[i].Amount > payouts[j].Amount
+	})
+
+	calculations := make([]sideBetCalculation, 0, len(payouts))
+	position := 0
+	for i, payout := range payouts {
+		if i == 0 || payout.Amount != payouts[i-1].Amount {
+			position = i + 1
+		}
+		data := models.NassauCalculationData{
+			Side:   sides[payout.Player.ID],
+			Amount: payout.Amount,
+		}
+		isWinner := final && payout.Amount > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: payout.Player.ID,
+			betType:  models.SideBetNassau,
+			data:     data,
+			position: position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+
+	return calculations
+}
+
+// validateNassauSides checks for two equal sides of one or two distinct players from the game
+func validateNassauSides(sides [][]string, players []models.PlayerSummary) error {
+	if len(sides) != 2 {
+		return errors.ValidationError("sides", fmt.Sprintf("%d sides", len(sides)), "must contain exactly two sides")
+	}
+	if len(sides[0]) != len(sides[1]) || len(sides[0]) < 1 || len(sides[0]) > 2 {
+		return errors.ValidationError("sides", fmt.Sprintf("%d vs %d", len(sides[0]), len(sides[1])), "sides must both have one player or both have two")
+	}
+
+	inGame := make(map[string]bool, len(players))
+	for _, player := range players {
+		inGame[player.ID] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, side := range sides {
+		for _, id := range side {
+			if !inGame[id] {
+				return errors.ValidationError("sides", id, "must be playing this side bet")
+			}
+			if seen[id] {
+				return errors.ValidationError("sides", id, "a player can only be on one side")
+			}
+			seen[id] = true
+		}
+	}
+
+	return nil
+}
+
+// nassauNotSetUpError is returned when a Nassau has no sides for the number of players
+func nassauNotSetUpError() error {
+	return errors.New(errors.ErrInvalidGameState, "Nassau sides must be set up with POST /side-bets/nassau/setup")
+}
+
+// nassauSideName returns the side identifier for a side index
+func nassauSideName(index int) string {
+	if index == 0 {
+		return nassauSideA
+	}
+	return nassauSideB
+}
+
+// roundedHandicap returns a handicap rounded to whole strokes
+func roundedHandicap(handicap *float64) int {
+	if handicap == nil {
+		return 0
+	}
+	return int(math.Round(*handicap))
+}
+
+// abs returns the absolute value of an integer
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+func TestDescribeNassauMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		margin      int
+		holesPlayed int
+		complete    bool
+		thru        int
+		wantStatus  string
+		wantWinner  string // Empty when the bet has no winner
+	}{
+		{name: "not started", thru: 0, wantStatus: "Not started"},
+		{name: "all square", holesPlayed: 4, thru: 4, wantStatus: "All square thru 4"},
+		{name: "leading", margin: 1, holesPlayed: 7, thru: 7, wantStatus: "1 up thru 7"},
+		{name: "trailing", margin: -2, holesPlayed: 5, thru: 5, wantStatus: "2 up thru 5"},
+		{name: "closed out", margin: 3, holesPlayed: 7, complete: true, thru: 7, wantStatus: "3&2", wantWinner: nassauSideA},
+		{name: "closed out by b", margin: -4, holesPlayed: 6, complete: true, thru: 6, wantStatus: "4&3", wantWinner: nassauSideB},
+		{name: "won on the last", margin: -2, holesPlayed: 9, complete: true, thru: 9, wantStatus: "2 up", wantWinner: nassauSideB},
+		{name: "halved", holesPlayed: 9, complete: true, thru: 9, wantStatus: "Halved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bet := &models.NassauMatch{StartHole: 1, EndHole: 9, Margin: tt.margin, HolesPlayed: tt.holesPlayed, Complete: tt.complete}
+			describeNassauMatch(bet, tt.thru)
+
+			if bet.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", bet.Status, tt.wantStatus)
+			}
+			winner := ""
+			if bet.Winner != nil {
+				winner = *bet.Winner
+			}
+			if winner != tt.wantWinner {
+				t.Errorf("winner = %q, want %q", winner, tt.wantWinner)
+			}
+		})
+	}
+}
+
+func TestNassauAutoPress(t *testing.T) {
+	tests := []struct {
+		name      string
+		autoPress bool
+		holes     [][2]int // Ann's and Bob's strokes on each hole from the first
+		want      map[string]string
+	}{
+		{
+			name:      "one down",
+			autoPress: true,
+			holes:     [][2]int{{4, 5}, {4, 4}},
+			want:      map[string]string{},
+		},
+		{
+			name:      "two down",
+			autoPress: true,
+			holes:     [][2]int{{4, 5}, {4, 5}},
+			want:      map[string]string{"front-press-3": nassauKindAutoPress, "overall-press-3": nassauKindAutoPress},
+		},
+		{
+			name:      "two down in a press",
+			autoPress: true,
+			holes:     [][2]int{{4, 5}, {4, 5}, {4, 5}, {4, 5}},
+			want: map[string]string{
+				"front-press-3":   nassauKindAutoPress,
+				"front-press-5":   nassauKindAutoPress,
+				"overall-press-3": nassauKindAutoPress,
+				"overall-press-5": nassauKindAutoPress,
+			},
+		},
+		{
+			name:      "presses turned off",
+			autoPress: false,
+			holes:     [][2]int{{4, 5}, {4, 5}},
+			want:      map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newNassauTestGame(t, tt.autoPress)
+			game.play(t, tt.holes...)
+
+			got := game.presses(t)
+			if len(got) != len(tt.want) {
+				t.Fatalf("presses = %v, want %v", got, tt.want)
+			}
+			for id, kind := range tt.want {
+				if got[id] != kind {
+					t.Errorf("press %s kind = %q, want %q", id, got[id], kind)
+				}
+			}
+		})
+	}
+}
+
+func TestNassauPress(t *testing.T) {
+	tests := []struct {
+		name    string
+		holes   [][2]int
+		bob     bool // Whether Bob calls the press rather than Ann
+		wantErr bool
+	}{
+		{name: "down side presses", holes: [][2]int{{4, 5}}, bob: true},
+		{name: "leading side refused", holes: [][2]int{{4, 5}}, wantErr: true},
+		{name: "all square refused", holes: [][2]int{{4, 4}}, bob: true, wantErr: true},
+		{name: "back to square refused", holes: [][2]int{{4, 5}, {5, 4}}, bob: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newNassauTestGame(t, false)
+			game.play(t, tt.holes...)
+
+			playerID := game.ann
+			if tt.bob {
+				playerID = game.bob
+			}
+			body, err := json.Marshal(models.NassauPressRequest{Segment: models.NassauFront, PlayerID: playerID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = game.nassau.Press(game.id, body)
+			if tt.wantErr {
+				apiErr, ok := err.(*errors.APIError)
+				if !ok || apiErr.Code != errors.ErrValidation {
+					t.Fatalf("press failed with %v, want %s", err, errors.ErrValidation)
+				}
+				if presses := game.presses(t); len(presses) != 0 {
+					t.Errorf("presses = %v, want none", presses)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			id := fmt.Sprintf("front-press-%d", len(tt.holes)+1)
+			if kind := game.presses(t)[id]; kind != nassauKindManualPress {
+				t.Errorf("press %s kind = %q, want %q", id, kind, nassauKindManualPress)
+			}
+		})
+	}
+}
+
+// nassauTestGame is a Nassau between Ann and Bob, playing off scratch
+type nassauTestGame struct {
+	nassau   *NassauBet
+	scores   *ScoreService
+	sideBets *SideBetService
+	id       string
+	ann, bob string
+}
+
+func newNassauTestGame(t *testing.T, autoPress bool) *nassauTestGame {
+	t.Helper()
+	db := newTestDB(t)
+
+	nassau := NewNassauBet(db, 5)
+	registry := NewSideBetRegistry(nassau)
+	games := NewGameService(db, registry)
+	players := NewPlayerService(db)
+	sideBets := NewSideBetService(db, registry)
+
+	created, err := games.CreateGame(&models.CreateGameRequest{
+		Course:   "diamond-run",
+		SideBets: []models.SideBetType{models.SideBetNassau},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game := &nassauTestGame{nassau: nassau, scores: NewScoreService(db, sideBets), sideBets: sideBets, id: created.ID}
+	for name, playerID := range map[string]*string{"Ann": &game.ann, "Bob": &game.bob} {
+		handicap := 0.0
+		player, err := players.AddPlayer(game.id, &models.CreatePlayerRequest{Name: name, Handicap: &handicap})
+		if err != nil {
+			t.Fatal(err)
+		}
+		*playerID = player.ID
+	}
+
+	body, err := json.Marshal(models.NassauSetupRequest{Sides: [][]string{{game.ann}, {game.bob}}, AutoPress: &autoPress})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nassau.Setup(game.id, body); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := games.StartGame(game.id); err != nil {
+		t.Fatal(err)
+	}
+	return game
+}
+
+// play records Ann's and Bob's strokes on each hole from the first
+func (g *nassauTestGame) play(t *testing.T, holes ...[2]int) {
+	t.Helper()
+	for i, strokes := range holes {
+		for j, playerID := range []string{g.ann, g.bob} {
+			score, err := g.scores.RecordScore(g.id, playerID, &models.ScoreRequest{Hole: i + 1, Strokes: strokes[j], Putts: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := g.sideBets.UpdateSideBetsForScore(g.id, playerID, score); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// presses returns the kind of every press in the standings by bet ID
+func (g *nassauTestGame) presses(t *testing.T) map[string]string {
+	t.Helper()
+	standings, err := g.nassau.calculateNassau(g.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	presses := make(map[string]string)
+	for _, bet := range standings.Bets {
+		if bet.Kind != nassauKindMatch {
+			presses[bet.ID] = bet.Kind
+		}
+	}
+	return presses
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"golf-gamez/internal/models"
@@ -64,6 +65,40 @@ func (s *PlayerService) AddPlayer(gameID string, req *models.CreatePlayerRequest
 		return nil, errors.New(errors.ErrDuplicatePlayerName, "Player name already exists in this game")
 	}
 
+	// A tracked golfer plays once per game and brings their handicap index
+	handicap, err := s.resolveHandicap(gameID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Play from the game's tee unless the player picked one rated for them
+	tee, err := s.validatePlayerTee(game, req.Tee, req.Gender)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assign team, creating it on first use
+	var teamID *string
+	if req.Team != nil {
+		if game.TeamFormat == nil {
+			return nil, errors.ValidationError("team", *req.Team, "game does not use teams")
+		}
+		id, err := s.findOrCreateTeam(gameID, *req.Team)
+		if err != nil {
+			return nil, err
+		}
+		teamID = &id
+	}
+
+	// Join every side bet in the game unless the player picked some
+	sideBets := game.SideBets
+	if req.SideBets != nil {
+		if err := validatePlayerSideBets(*req.SideBets, game.SideBets); err != nil {
+			return nil, err
+		}
+		sideBets = *req.SideBets
+	}
+
 	// Generate player ID
 	playerID, err := auth.GeneratePlayerID()
 	if err != nil {
@@ -78,16 +113,25 @@ func (s *PlayerService) AddPlayer(gameID string, req *models.CreatePlayerRequest
 		ID:        playerID,
 		GameID:    gameID,
 		Name:      req.Name,
-		Handicap:  req.Handicap,
+		Handicap:  handicap,
 		Gender:    req.Gender,
+		GolferID:  req.GolferID,
+		Tee:       tee,
 		Position:  position,
+		TeamID:    teamID,
+		SideBets:  sideBets,
 		CreatedAt: time.Now(),
 	}
 
+	sideBetsJSON, err := player.MarshalSideBets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal side bets: %w", err)
+	}
+
 	// Insert into database
 	query := `
-		INSERT INTO players (id, game_id, name, handicap, gender, position, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO players (id, game_id, name, handicap, gender, golfer_id, tee, position, team_id, side_bets, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var genderValue interface{}
@@ -102,13 +146,23 @@ func (s *PlayerService) AddPlayer(gameID string, req *models.CreatePlayerRequest
 		player.Name,
 		player.Handicap,
 		genderValue,
+		player.GolferID,
+		player.Tee,
 		player.Position,
+		player.TeamID,
+		sideBetsJSON,
 		player.CreatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert player: %w", err)
 	}
 
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game handicaps: %w", err)
+	}
+	handicaps.applyToPlayer(player)
+
 	log.Info().
 		Str("player_id", playerID).
 		Str("game_id", gameID).
@@ -126,10 +180,12 @@ func (s *PlayerService) GetPlayers(gameID string) (*models.PlayersResponse, erro
 	}
 
 	query := `
-		SELECT id, game_id, name, handicap, gender, position, created_at
-		FROM players
-		WHERE game_id = ?
-		ORDER BY position
+		SELECT p.id, p.game_id, p.name, p.handicap, p.gender, p.golfer_id, p.tee, p.position, p.team_id,
+		       COALESCE(p.side_bets, g.side_bets), p.created_at
+		FROM players p
+		JOIN games g ON g.id = p.game_id
+		WHERE p.game_id = ?
+		ORDER BY p.position
 	`
 	rows, err := s.db.Query(query, gameID)
 	if err != nil {
@@ -137,10 +193,16 @@ func (s *PlayerService) GetPlayers(gameID string) (*models.PlayersResponse, erro
 	}
 	defer rows.Close()
 
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game handicaps: %w", err)
+	}
+
 	var players []models.Player
 	for rows.Next() {
 		var player models.Player
 		var gender sql.NullString
+		var sideBetsJSON string
 
 		err := rows.Scan(
 			&player.ID,
@@ -148,7 +210,11 @@ func (s *PlayerService) GetPlayers(gameID string) (*models.PlayersResponse, erro
 			&player.Name,
 			&player.Handicap,
 			&gender,
+			&player.GolferID,
+			&player.Tee,
 			&player.Position,
+			&player.TeamID,
+			&sideBetsJSON,
 			&player.CreatedAt,
 		)
 		if err != nil {
@@ -160,6 +226,11 @@ func (s *PlayerService) GetPlayers(gameID string) (*models.PlayersResponse, erro
 			player.Gender = &g
 		}
 
+		if err := player.UnmarshalSideBets(sideBetsJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal side bets: %w", err)
+		}
+		handicaps.applyToPlayer(&player)
+
 		// Load player stats
 		stats, err := s.getPlayerStats(player.ID)
 		if err != nil {
@@ -185,13 +256,16 @@ func (s *PlayerService) GetPlayer(gameID, playerID string) (*models.PlayerDetail
 	}
 
 	query := `
-		SELECT id, game_id, name, handicap, gender, position, created_at
-		FROM players
-		WHERE id = ? AND game_id = ?
+		SELECT p.id, p.game_id, p.name, p.handicap, p.gender, p.golfer_id, p.tee, p.position, p.team_id,
+		       COALESCE(p.side_bets, g.side_bets), p.created_at
+		FROM players p
+		JOIN games g ON g.id = p.game_id
+		WHERE p.id = ? AND p.game_id = ?
 	`
 
 	var player models.Player
 	var gender sql.NullString
+	var sideBetsJSON string
 
 	err := s.db.QueryRow(query, playerID, gameID).Scan(
 		&player.ID,
@@ -199,7 +273,11 @@ func (s *PlayerService) GetPlayer(gameID, playerID string) (*models.PlayerDetail
 		&player.Name,
 		&player.Handicap,
 		&gender,
+		&player.GolferID,
+		&player.Tee,
 		&player.Position,
+		&player.TeamID,
+		&sideBetsJSON,
 		&player.CreatedAt,
 	)
 	if err != nil {
@@ -214,6 +292,16 @@ func (s *PlayerService) GetPlayer(gameID, playerID string) (*models.PlayerDetail
 		player.Gender = &g
 	}
 
+	if err := player.UnmarshalSideBets(sideBetsJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal side bets: %w", err)
+	}
+
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game handicaps: %w", err)
+	}
+	handicaps.applyToPlayer(&player)
+
 	// Load player stats
 	stats, err := s.getPlayerStats(player.ID)
 	if err != nil {
@@ -242,7 +330,8 @@ func (s *PlayerService) UpdatePlayer(gameID, playerID string, req *models.Update
 	}
 
 	// Check game exists and player exists
-	if _, err := s.getGameForUpdate(gameID); err != nil {
+	game, err := s.getGameForUpdate(gameID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -263,6 +352,36 @@ func (s *PlayerService) UpdatePlayer(gameID, playerID string, req *models.Update
 		}
 	}
 
+	// Side bets are settled before the game starts
+	if req.SideBets != nil {
+		if game.Status != models.GameStatusSetup {
+			return nil, errors.BusinessLogicError(
+				errors.ErrInvalidGameState,
+				"Cannot change side bets after game has started",
+				string(game.Status),
+				string(models.GameStatusSetup),
+			)
+		}
+		if err := validatePlayerSideBets(*req.SideBets, game.SideBets); err != nil {
+			return nil, err
+		}
+	}
+
+	// Tees are picked before the game starts, so no hole has been scored off them
+	if req.Tee != nil {
+		if game.Status != models.GameStatusSetup {
+			return nil, errors.BusinessLogicError(
+				errors.ErrInvalidGameState,
+				"Cannot change tees after game has started",
+				string(game.Status),
+				string(models.GameStatusSetup),
+			)
+		}
+		if _, err := s.validatePlayerTee(game, req.Tee, player.Gender); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build update query dynamically
 	setParts := []string{}
 	args := []interface{}{}
@@ -277,6 +396,20 @@ func (s *PlayerService) UpdatePlayer(gameID, playerID string, req *models.Update
 		args = append(args, *req.Handicap)
 	}
 
+	if req.Tee != nil {
+		setParts = append(setParts, "tee = ?")
+		args = append(args, *req.Tee)
+	}
+
+	if req.SideBets != nil {
+		sideBetsJSON, err := (&models.Player{SideBets: *req.SideBets}).MarshalSideBets()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal side bets: %w", err)
+		}
+		setParts = append(setParts, "side_bets = ?")
+		args = append(args, sideBetsJSON)
+	}
+
 	if len(setParts) == 0 {
 		// No updates needed, return current player
 		return &player.Player, nil
@@ -287,17 +420,21 @@ func (s *PlayerService) UpdatePlayer(gameID, playerID string, req *models.Update
 
 	query := fmt.Sprintf(
 		"UPDATE players SET %s WHERE id = ? AND game_id = ?",
-		fmt.Sprintf("%s", setParts[0]),
+		strings.Join(setParts, ", "),
 	)
-	for i := 1; i < len(setParts); i++ {
-		query = fmt.Sprintf("%s, %s", query[:len(query)-20], setParts[i]) + " WHERE id = ? AND game_id = ?"
-	}
 
 	_, err = s.db.Exec(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update player: %w", err)
 	}
 
+	// Rescore holes already played with the new handicap strokes
+	if req.Handicap != nil {
+		if err := NewScoreService(s.db, nil).recalculateHandicapStrokes(gameID, playerID); err != nil {
+			return nil, fmt.Errorf("failed to recalculate handicap strokes: %w", err)
+		}
+	}
+
 	// Return updated player
 	updatedPlayer, err := s.GetPlayer(gameID, playerID)
 	if err != nil {
@@ -356,6 +493,14 @@ func (s *PlayerService) RemovePlayer(gameID, playerID string) error {
 		log.Warn().Err(err).Msg("Failed to reorder player positions")
 	}
 
+	// Drop teams left without players
+	if _, err := s.db.Exec(`
+		DELETE FROM teams
+		WHERE game_id = ? AND id NOT IN (SELECT team_id FROM players WHERE game_id = ? AND team_id IS NOT NULL)
+	`, gameID, gameID); err != nil {
+		log.Warn().Err(err).Msg("Failed to remove empty teams")
+	}
+
 	log.Info().
 		Str("player_id", playerID).
 		Str("game_id", gameID).
@@ -375,8 +520,21 @@ func (s *PlayerService) validateCreatePlayerRequest(req *models.CreatePlayerRequ
 		return errors.ValidationError("name", req.Name, "must be 100 characters or less")
 	}
 
-	if req.Handicap < 0 || req.Handicap > 54 {
-		return errors.ValidationError("handicap", fmt.Sprintf("%.1f", req.Handicap), "must be between 0 and 54")
+	if req.Handicap == nil && req.GolferID == nil {
+		return errors.ValidationError("handicap", "", "is required unless golfer_id is given")
+	}
+
+	if req.Handicap != nil && (*req.Handicap < -10 || *req.Handicap > 54) {
+		return errors.ValidationError("handicap", fmt.Sprintf("%.1f", *req.Handicap), "must be between -10 and 54")
+	}
+
+	if req.Team != nil {
+		if *req.Team == "" {
+			return errors.ValidationError("team", "", "cannot be empty")
+		}
+		if len(*req.Team) > 50 {
+			return errors.ValidationError("team", *req.Team, "must be 50 characters or less")
+		}
 	}
 
 	if req.Gender != nil {
@@ -392,6 +550,49 @@ func (s *PlayerService) validateCreatePlayerRequest(req *models.CreatePlayerRequ
 	return nil
 }
 
+// resolveHandicap returns the handicap index a new player plays off: the one
+// requested, or else their tracked golfer's current index
+func (s *PlayerService) resolveHandicap(gameID string, req *models.CreatePlayerRequest) (float64, error) {
+	if req.GolferID == nil {
+		return *req.Handicap, nil
+	}
+
+	index, err := NewGolferService(s.db).currentIndex(*req.GolferID)
+	if err != nil {
+		return 0, err
+	}
+
+	var playing bool
+	err = s.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM players WHERE game_id = ? AND golfer_id = ?)",
+		gameID, *req.GolferID,
+	).Scan(&playing)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check golfer: %w", err)
+	}
+	if playing {
+		return 0, errors.New(errors.ErrDuplicateGolfer, "Golfer is already playing in this game")
+	}
+
+	switch {
+	case req.Handicap != nil:
+		return *req.Handicap, nil
+	case index != nil:
+		return *index, nil
+	default:
+		return 0, errors.ValidationError("handicap", "", "is required until the golfer has a handicap index")
+	}
+}
+
+// validatePlayerTee resolves the tee a player plays from on the game's course
+func (s *PlayerService) validatePlayerTee(game *models.Game, tee *string, gender *models.Gender) (string, error) {
+	tees, err := (&sideBetStore{db: s.db}).getTeeSets(game.Course)
+	if err != nil {
+		return "", fmt.Errorf("failed to load tee sets: %w", err)
+	}
+	return validatePlayerTee(tee, game.Tee, gender, tees)
+}
+
 func (s *PlayerService) validateUpdatePlayerRequest(req *models.UpdatePlayerRequest) error {
 	if req.Name != nil {
 		if *req.Name == "" {
@@ -403,24 +604,57 @@ func (s *PlayerService) validateUpdatePlayerRequest(req *models.UpdatePlayerRequ
 	}
 
 	if req.Handicap != nil {
-		if *req.Handicap < 0 || *req.Handicap > 54 {
-			return errors.ValidationError("handicap", fmt.Sprintf("%.1f", *req.Handicap), "must be between 0 and 54")
+		if *req.Handicap < -10 || *req.Handicap > 54 {
+			return errors.ValidationError("handicap", fmt.Sprintf("%.1f", *req.Handicap), "must be between -10 and 54")
 		}
 	}
 
 	return nil
 }
 
+// validatePlayerSideBets checks a player's side bets are among the game's
+// side bets and listed once each. An empty list sits out every side bet.
+func validatePlayerSideBets(sideBets, gameSideBets []models.SideBetType) error {
+	allowed := make([]interface{}, len(gameSideBets))
+	inGame := make(map[models.SideBetType]bool, len(gameSideBets))
+	for i, sideBet := range gameSideBets {
+		allowed[i] = sideBet
+		inGame[sideBet] = true
+	}
+
+	seen := make(map[models.SideBetType]bool, len(sideBets))
+	for _, sideBet := range sideBets {
+		if !inGame[sideBet] {
+			return errors.ValidationErrorWithAllowedValues("side_bets", string(sideBet), allowed)
+		}
+		if seen[sideBet] {
+			return errors.ValidationError("side_bets", string(sideBet), "must not be listed more than once")
+		}
+		seen[sideBet] = true
+	}
+
+	return nil
+}
+
 func (s *PlayerService) getGameForUpdate(gameID string) (*models.Game, error) {
 	var game models.Game
-	query := `SELECT id, status FROM games WHERE id = ?`
-	err := s.db.QueryRow(query, gameID).Scan(&game.ID, &game.Status)
+	var teamFormat sql.NullString
+	var sideBetsJSON string
+	query := `SELECT id, course, tee, status, team_format, side_bets FROM games WHERE id = ?`
+	err := s.db.QueryRow(query, gameID).Scan(&game.ID, &game.Course, &game.Tee, &game.Status, &teamFormat, &sideBetsJSON)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ResourceNotFoundError("Game", gameID)
 		}
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
+	if teamFormat.Valid {
+		format := models.TeamFormat(teamFormat.String)
+		game.TeamFormat = &format
+	}
+	if err := game.UnmarshalSideBets(sideBetsJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal side bets: %w", err)
+	}
 	return &game, nil
 }
 
@@ -438,12 +672,62 @@ func (s *PlayerService) playerNameExists(gameID, name string) (bool, error) {
 	return count > 0, err
 }
 
+// findOrCreateTeam returns the ID of the named team, creating it if needed.
+// Teams are limited to two players.
+func (s *PlayerService) findOrCreateTeam(gameID, name string) (string, error) {
+	var teamID string
+	err := s.db.QueryRow(`SELECT id FROM teams WHERE game_id = ? AND name = ?`, gameID, name).Scan(&teamID)
+	if err == nil {
+		var members int
+		if err := s.db.QueryRow(`SELECT COUNT(*) FROM players WHERE team_id = ?`, teamID).Scan(&members); err != nil {
+			return "", fmt.Errorf("failed to count team players: %w", err)
+		}
+		if members >= maxTeamPlayers {
+			return "", errors.NewWithDetails(errors.ErrPlayerLimitExceeded, "Maximum 2 players allowed per team", map[string]interface{}{
+				"team": name,
+			})
+		}
+		return teamID, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to get team: %w", err)
+	}
+
+	teamID, err = auth.GenerateTeamID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate team ID: %w", err)
+	}
+
+	var teamCount int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM teams WHERE game_id = ?`, gameID).Scan(&teamCount); err != nil {
+		return "", fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO teams (id, game_id, name, position, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, teamID, gameID, name, teamCount+1, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to insert team: %w", err)
+	}
+
+	log.Info().
+		Str("team_id", teamID).
+		Str("game_id", gameID).
+		Str("name", name).
+		Msg("Team created successfully")
+
+	return teamID, nil
+}
+
 func (s *PlayerService) getPlayerStats(playerID string) (*models.PlayerStats, error) {
 	// This is a simplified version - would need more complex logic for full stats
 	query := `
 		SELECT COUNT(*) as holes_completed,
 		       COALESCE(SUM(score_to_par), 0) as total_score,
-		       COALESCE(SUM(putts), 0) as total_putts
+		       COALESCE(SUM(putts), 0) as total_putts,
+		       COALESCE(SUM(strokes), 0) as total_strokes,
+		       COALESCE(SUM(adjusted_strokes), 0) as adjusted_strokes
 		FROM scores
 		WHERE player_id = ?
 	`
@@ -451,8 +735,10 @@ func (s *PlayerService) getPlayerStats(playerID string) (*models.PlayerStats, er
 	var holesCompleted int
 	var totalScore int
 	var totalPutts int
+	var totalStrokes int
+	var adjustedStrokes int
 
-	err := s.db.QueryRow(query, playerID).Scan(&holesCompleted, &totalScore, &totalPutts)
+	err := s.db.QueryRow(query, playerID).Scan(&holesCompleted, &totalScore, &totalPutts, &totalStrokes, &adjustedStrokes)
 	if err != nil {
 		return nil, err
 	}
@@ -468,16 +754,18 @@ func (s *PlayerService) getPlayerStats(playerID string) (*models.PlayerStats, er
 	}
 
 	return &models.PlayerStats{
-		HolesCompleted: holesCompleted,
-		CurrentScore:   currentScore,
-		TotalPutts:     totalPutts,
+		HolesCompleted:  holesCompleted,
+		CurrentScore:    currentScore,
+		TotalPutts:      totalPutts,
+		TotalStrokes:    totalStrokes,
+		AdjustedStrokes: adjustedStrokes,
 	}, nil
 }
 
 func (s *PlayerService) getPlayerScores(playerID string) ([]models.Score, error) {
 	query := `
-		SELECT id, player_id, game_id, hole, strokes, putts, par,
-		       handicap_stroke, score_to_par, effective_score,
+		SELECT id, player_id, game_id, hole, strokes, adjusted_strokes, putts, par,
+		       handicap_strokes, score_to_par, effective_score,
 		       created_at, updated_at
 		FROM scores
 		WHERE player_id = ?
@@ -501,9 +789,10 @@ func (s *PlayerService) getPlayerScores(playerID string) ([]models.Score, error)
 			&score.GameID,
 			&score.Hole,
 			&score.Strokes,
+			&score.AdjustedStrokes,
 			&score.Putts,
 			&score.Par,
-			&score.HandicapStroke,
+			&score.HandicapStrokes,
 			&score.ScoreToPar,
 			&score.EffectiveScore,
 			&score.CreatedAt,
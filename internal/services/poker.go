@@ -0,0 +1,444 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golf-gamez/internal/models"
+)
+
+// Putt Putt Poker deals are provably fair: a random seed is generated when the
+// game starts and only its SHA-256 hash is published. At deal time the seed is
+// revealed and anyone can re-run shuffleDeck with it to reproduce the deal.
+//
+// The shuffle is a Fisher-Yates shuffle over the canonical deck order (suits
+// S, H, D, C; ranks 2 through A). Random numbers are drawn as big-endian
+// uint64s from the stream SHA-256(seed || counter), with counter a big-endian
+// uint64 starting at zero, using rejection sampling to avoid modulo bias.
+
+const (
+	// pokerSeedBytes is the size of the random seed used for a deal
+	pokerSeedBytes = 32
+
+	// pokerHandSize is the number of cards in a poker hand
+	pokerHandSize = 5
+)
+
+var (
+	cardRanks = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
+	cardSuits = []string{"S", "H", "D", "C"}
+
+	rankNames = map[int]string{
+		2: "two", 3: "three", 4: "four", 5: "five", 6: "six", 7: "seven", 8: "eight",
+		9: "nine", 10: "ten", 11: "jack", 12: "queen", 13: "king", 14: "ace",
+	}
+	rankPlurals = map[int]string{
+		2: "twos", 3: "threes", 4: "fours", 5: "fives", 6: "sixes", 7: "sevens", 8: "eights",
+		9: "nines", 10: "tens", 11: "jacks", 12: "queens", 13: "kings", 14: "aces",
+	}
+
+	handRanks = map[models.PokerHandType]int{
+		models.HighCard:      1,
+		models.Pair:          2,
+		models.TwoPair:       3,
+		models.ThreeOfAKind:  4,
+		models.Straight:      5,
+		models.Flush:         6,
+		models.FullHouse:     7,
+		models.FourOfAKind:   8,
+		models.StraightFlush: 9,
+		models.RoyalFlush:    10,
+	}
+)
+
+// generatePokerSeed creates a new random seed and returns it with its published hash
+func generatePokerSeed() (seed string, seedHash string, err error) {
+	bytes := make([]byte, pokerSeedBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	seed = hex.EncodeToString(bytes)
+	return seed, hashPokerSeed(seed), nil
+}
+
+// commitPokerSeed generates and stores a game's deal seed if it doesn't
+// already have one, returning the published seed hash
+func commitPokerSeed(db *sql.DB, gameID string) (string, error) {
+	seed, seedHash, err := generatePokerSeed()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = db.Exec(`
+		INSERT OR IGNORE INTO poker_seeds (game_id, seed, seed_hash)
+		VALUES (?, ?, ?)
+	`, gameID, seed, seedHash)
+	if err != nil {
+		return "", err
+	}
+
+	err = db.QueryRow("SELECT seed_hash FROM poker_seeds WHERE game_id = ?", gameID).Scan(&seedHash)
+	return seedHash, err
+}
+
+// hashPokerSeed returns the hex SHA-256 commitment for a hex encoded seed
+func hashPokerSeed(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// seedStream is a deterministic random number source derived from a seed
+type seedStream struct {
+	seed    []byte
+	counter uint64
+	buffer  []byte
+}
+
+func (r *seedStream) uint64() uint64 {
+	if len(r.buffer) < 8 {
+		block := make([]byte, 0, len(r.seed)+8)
+		block = append(block, r.seed...)
+		block = binary.BigEndian.AppendUint64(block, r.counter)
+		sum := sha256.Sum256(block)
+		r.buffer = append(r.buffer, sum[:]...)
+		r.counter++
+	}
+	value := binary.BigEndian.Uint64(r.buffer[:8])
+	r.buffer = r.buffer[8:]
+	return value
+}
+
+// intn returns a uniformly distributed value in [0, n)
+func (r *seedStream) intn(n int) int {
+	max := ^uint64(0)
+	limit := max - max%uint64(n)
+	for {
+		value := r.uint64()
+		if value < limit {
+			return int(value % uint64(n))
+		}
+	}
+}
+
+// shuffleDeck shuffles enough 52-card decks to deal the requested number of
+// cards. A single deck is used unless more than 52 cards are needed.
+func shuffleDeck(seed string, cardsNeeded int) ([]string, error) {
+	seedBytes, err := hex.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid poker seed: %w", err)
+	}
+
+	deckSize := len(cardRanks) * len(cardSuits)
+	decks := 1
+	if cardsNeeded > deckSize {
+		decks = (cardsNeeded + deckSize - 1) / deckSize
+	}
+
+	deck := make([]string, 0, decks*deckSize)
+	for d := 0; d < decks; d++ {
+		for _, suit := range cardSuits {
+			for _, rank := range cardRanks {
+				deck = append(deck, rank+suit)
+			}
+		}
+	}
+
+	stream := &seedStream{seed: seedBytes}
+	for i := len(deck) - 1; i > 0; i-- {
+		j := stream.intn(i + 1)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+
+	return deck, nil
+}
+
+// pokerCard is a parsed card with numeric rank (2-14, ace high)
+type pokerCard struct {
+	label string
+	rank  int
+	suit  string
+}
+
+func parsePokerCard(label string) (pokerCard, error) {
+	if len(label) < 2 {
+		return pokerCard{}, fmt.Errorf("invalid card %q", label)
+	}
+	rankLabel, suit := label[:len(label)-1], label[len(label)-1:]
+	for _, cardSuit := range cardSuits {
+		if cardSuit != suit {
+			continue
+		}
+		for i, r := range cardRanks {
+			if r == rankLabel {
+				return pokerCard{label: label, rank: i + 2, suit: suit}, nil
+			}
+		}
+	}
+	return pokerCard{}, fmt.Errorf("invalid card %q", label)
+}
+
+// evaluatePokerHand finds the best five-card hand that can be made from any
+// number of dealt cards. Hands with fewer than five cards are ranked on the
+// cards available. The returned key orders hands: higher compares better.
+func evaluatePokerHand(dealt []string) (models.BestPokerHand, []int, error) {
+	cards := make([]pokerCard, 0, len(dealt))
+	for _, label := range dealt {
+		card, err := parsePokerCard(label)
+		if err != nil {
+			return models.BestPokerHand{}, nil, err
+		}
+		cards = append(cards, card)
+	}
+
+	// Highest rank first so every selection below picks top cards
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].rank > cards[j].rank })
+
+	bySuit := map[string][]pokerCard{}
+	byRank := map[int][]pokerCard{}
+	for _, card := range cards {
+		bySuit[card.suit] = append(bySuit[card.suit], card)
+		byRank[card.rank] = append(byRank[card.rank], card)
+	}
+
+	// Straight flush, including royal flush
+	var bestStraightFlush []pokerCard
+	for _, suit := range cardSuits {
+		if straight := findStraight(bySuit[suit]); straight != nil {
+			if bestStraightFlush == nil || straightHigh(straight) > straightHigh(bestStraightFlush) {
+				bestStraightFlush = straight
+			}
+		}
+	}
+	if bestStraightFlush != nil {
+		high := straightHigh(bestStraightFlush)
+		if high == 14 {
+			return buildHand(models.RoyalFlush, bestStraightFlush, []int{high}, "Royal flush")
+		}
+		return buildHand(models.StraightFlush, bestStraightFlush, []int{high},
+			fmt.Sprintf("%s-high straight flush", capitalize(rankNames[high])))
+	}
+
+	groups := rankGroups(byRank)
+
+	// Four of a kind
+	if len(groups) > 0 && len(byRank[groups[0]]) >= 4 {
+		quad := append([]pokerCard{}, byRank[groups[0]][:4]...)
+		kickers := takeKickers(cards, []int{groups[0]}, 1)
+		return buildHand(models.FourOfAKind, append(quad, kickers...), appendRanks([]int{groups[0]}, kickers),
+			fmt.Sprintf("Four of a kind, %s", rankPlurals[groups[0]]))
+	}
+
+	// Full house: best trips plus the best remaining pair (or trips)
+	if len(groups) > 0 && len(byRank[groups[0]]) >= 3 {
+		for _, rank := range groups[1:] {
+			if len(byRank[rank]) >= 2 {
+				hand := append(append([]pokerCard{}, byRank[groups[0]][:3]...), byRank[rank][:2]...)
+				return buildHand(models.FullHouse, hand, []int{groups[0], rank},
+					fmt.Sprintf("Full house, %s over %s", rankPlurals[groups[0]], rankPlurals[rank]))
+			}
+		}
+	}
+
+	// Flush: compare the top five cards of each eligible suit
+	var bestFlush []pokerCard
+	for _, suit := range cardSuits {
+		suited := bySuit[suit]
+		if len(suited) < pokerHandSize {
+			continue
+		}
+		candidate := suited[:pokerHandSize]
+		if bestFlush == nil || comparePokerKeys(cardRanksOf(candidate), cardRanksOf(bestFlush)) > 0 {
+			bestFlush = candidate
+		}
+	}
+	if bestFlush != nil {
+		return buildHand(models.Flush, bestFlush, cardRanksOf(bestFlush),
+			fmt.Sprintf("%s-high flush", capitalize(rankNames[bestFlush[0].rank])))
+	}
+
+	// Straight
+	if straight := findStraight(cards); straight != nil {
+		high := straightHigh(straight)
+		return buildHand(models.Straight, straight, []int{high},
+			fmt.Sprintf("%s-high straight", capitalize(rankNames[high])))
+	}
+
+	// Three of a kind
+	if len(groups) > 0 && len(byRank[groups[0]]) >= 3 {
+		kickers := takeKickers(cards, []int{groups[0]}, 2)
+		return buildHand(models.ThreeOfAKind, append(append([]pokerCard{}, byRank[groups[0]][:3]...), kickers...),
+			appendRanks([]int{groups[0]}, kickers),
+			fmt.Sprintf("Three of a kind, %s", rankPlurals[groups[0]]))
+	}
+
+	// Two pair
+	if len(groups) > 1 {
+		high, low := groups[0], groups[1]
+		kickers := takeKickers(cards, []int{high, low}, 1)
+		hand := append(append(append([]pokerCard{}, byRank[high][:2]...), byRank[low][:2]...), kickers...)
+		return buildHand(models.TwoPair, hand, appendRanks([]int{high, low}, kickers),
+			fmt.Sprintf("Two pair, %s and %s", rankPlurals[high], rankPlurals[low]))
+	}
+
+	// Pair
+	if len(groups) == 1 {
+		kickers := takeKickers(cards, []int{groups[0]}, 3)
+		return buildHand(models.Pair, append(append([]pokerCard{}, byRank[groups[0]][:2]...), kickers...),
+			appendRanks([]int{groups[0]}, kickers),
+			fmt.Sprintf("Pair of %s", rankPlurals[groups[0]]))
+	}
+
+	// High card
+	hand := cards
+	if len(hand) > pokerHandSize {
+		hand = hand[:pokerHandSize]
+	}
+	if len(hand) == 0 {
+		return buildHand(models.HighCard, hand, nil, "No cards")
+	}
+	return buildHand(models.HighCard, hand, cardRanksOf(hand),
+		fmt.Sprintf("%s high", capitalize(rankNames[hand[0].rank])))
+}
+
+// comparePokerKeys returns 1 if a beats b, -1 if b beats a and 0 for a tie
+func comparePokerKeys(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	switch {
+	case len(a) > len(b):
+		return 1
+	case len(a) < len(b):
+		return -1
+	default:
+		return 0
+	}
+}
+
+func buildHand(handType models.PokerHandType, cards []pokerCard, tiebreak []int, description string) (models.BestPokerHand, []int, error) {
+	labels := make([]string, 0, len(cards))
+	for _, card := range cards {
+		labels = append(labels, card.label)
+	}
+
+	rank := handRanks[handType]
+	key := append([]int{rank}, tiebreak...)
+
+	return models.BestPokerHand{
+		Cards:       labels,
+		HandType:    handType,
+		HandRank:    rank,
+		Description: description,
+	}, key, nil
+}
+
+// rankGroups returns ranks held more than once, ordered by group size
+// (quads, then trips, then pairs) and then by rank
+func rankGroups(byRank map[int][]pokerCard) []int {
+	size := func(rank int) int {
+		if n := len(byRank[rank]); n < 4 {
+			return n
+		}
+		return 4
+	}
+
+	var ranks []int
+	for rank := range byRank {
+		if size(rank) >= 2 {
+			ranks = append(ranks, rank)
+		}
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		if size(ranks[i]) != size(ranks[j]) {
+			return size(ranks[i]) > size(ranks[j])
+		}
+		return ranks[i] > ranks[j]
+	})
+	return ranks
+}
+
+// findStraight returns the highest five-card straight in the cards, if any.
+// Cards must be sorted by descending rank; the ace also plays low.
+func findStraight(cards []pokerCard) []pokerCard {
+	byRank := map[int]pokerCard{}
+	for _, card := range cards {
+		if _, ok := byRank[card.rank]; !ok {
+			byRank[card.rank] = card
+		}
+	}
+	if ace, ok := byRank[14]; ok {
+		byRank[1] = ace
+	}
+
+	for high := 14; high >= 5; high-- {
+		straight := make([]pokerCard, 0, pokerHandSize)
+		for rank := high; rank > high-pokerHandSize; rank-- {
+			card, ok := byRank[rank]
+			if !ok {
+				break
+			}
+			straight = append(straight, card)
+		}
+		if len(straight) == pokerHandSize {
+			return straight
+		}
+	}
+	return nil
+}
+
+// straightHigh returns the top rank of a straight; A-2-3-4-5 is five high
+// because findStraight places the low ace last
+func straightHigh(straight []pokerCard) int {
+	return straight[0].rank
+}
+
+// takeKickers returns the highest cards not in the excluded ranks
+func takeKickers(cards []pokerCard, exclude []int, count int) []pokerCard {
+	var kickers []pokerCard
+	for _, card := range cards {
+		if len(kickers) == count {
+			break
+		}
+		excluded := false
+		for _, rank := range exclude {
+			if card.rank == rank {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kickers = append(kickers, card)
+		}
+	}
+	return kickers
+}
+
+func appendRanks(ranks []int, cards []pokerCard) []int {
+	return append(ranks, cardRanksOf(cards)...)
+}
+
+func cardRanksOf(cards []pokerCard) []int {
+	ranks := make([]int, 0, len(cards))
+	for _, card := range cards {
+		ranks = append(ranks, card.rank)
+	}
+	return ranks
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+func TestEvaluatePokerHand(t *testing.T) {
+	tests := []struct {
+		name        string
+		dealt       []string
+		handType    models.PokerHandType
+		key         []int
+		description string
+	}{
+		{"royal flush", []string{"AS", "KS", "QS", "JS", "10S", "2H", "3D"}, models.RoyalFlush, []int{10, 14}, "Royal flush"},
+		{"straight flush", []string{"9H", "8H", "7H", "6H", "5H", "KD"}, models.StraightFlush, []int{9, 9}, "Nine-high straight flush"},
+		{"steel wheel", []string{"AD", "2D", "3D", "4D", "5D"}, models.StraightFlush, []int{9, 5}, "Five-high straight flush"},
+		{"four of a kind", []string{"7S", "7H", "7D", "7C", "KS", "2H"}, models.FourOfAKind, []int{8, 7, 13}, "Four of a kind, sevens"},
+		{"full house", []string{"QS", "QH", "QD", "4C", "4S", "AH"}, models.FullHouse, []int{7, 12, 4}, "Full house, queens over fours"},
+		{"full house from two trips", []string{"5S", "5H", "5D", "9C", "9S", "9H"}, models.FullHouse, []int{7, 9, 5}, "Full house, nines over fives"},
+		{"flush", []string{"KC", "10C", "8C", "4C", "2C", "3C", "AS"}, models.Flush, []int{6, 13, 10, 8, 4, 3}, "King-high flush"},
+		{"straight", []string{"10S", "9H", "8D", "7C", "6S", "2H"}, models.Straight, []int{5, 10}, "Ten-high straight"},
+		{"ace-high straight", []string{"AS", "KH", "QD", "JC", "10S"}, models.Straight, []int{5, 14}, "Ace-high straight"},
+		{"wheel", []string{"AS", "2H", "3D", "4C", "5S", "KH"}, models.Straight, []int{5, 5}, "Five-high straight"},
+		{"three of a kind", []string{"8S", "8H", "8D", "KC", "3S", "2H"}, models.ThreeOfAKind, []int{4, 8, 13, 3}, "Three of a kind, eights"},
+		{"two pair", []string{"JS", "JH", "4D", "4C", "9S", "2H"}, models.TwoPair, []int{3, 11, 4, 9}, "Two pair, jacks and fours"},
+		{"two pair from three pairs", []string{"JS", "JH", "4D", "4C", "9S", "9H", "2C"}, models.TwoPair, []int{3, 11, 9, 4}, "Two pair, jacks and nines"},
+		{"pair", []string{"6S", "6H", "AD", "QC", "9S", "2H"}, models.Pair, []int{2, 6, 14, 12, 9}, "Pair of sixes"},
+		{"high card", []string{"AS", "JH", "9D", "6C", "4S", "2H"}, models.HighCard, []int{1, 14, 11, 9, 6, 4}, "Ace high"},
+		{"four cards", []string{"KS", "KH", "5D", "2C"}, models.Pair, []int{2, 13, 5, 2}, "Pair of kings"},
+		{"four to a straight", []string{"9S", "8H", "7D", "6C"}, models.HighCard, []int{1, 9, 8, 7, 6}, "Nine high"},
+		{"three cards", []string{"QS", "QH", "QD"}, models.ThreeOfAKind, []int{4, 12}, "Three of a kind, queens"},
+		{"one card", []string{"3D"}, models.HighCard, []int{1, 3}, "Three high"},
+		{"no cards", nil, models.HighCard, []int{1}, "No cards"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand, key, err := evaluatePokerHand(tt.dealt)
+			if err != nil {
+				t.Fatalf("evaluatePokerHand(%v) error: %v", tt.dealt, err)
+			}
+			if hand.HandType != tt.handType {
+				t.Errorf("hand type = %s, want %s", hand.HandType, tt.handType)
+			}
+			if hand.HandRank != handRanks[tt.handType] {
+				t.Errorf("hand rank = %d, want %d", hand.HandRank, handRanks[tt.handType])
+			}
+			if !reflect.DeepEqual(key, tt.key) {
+				t.Errorf("key = %v, want %v", key, tt.key)
+			}
+			if hand.Description != tt.description {
+				t.Errorf("description = %q, want %q", hand.Description, tt.description)
+			}
+			want := min(len(tt.dealt), pokerHandSize)
+			if len(hand.Cards) != want {
+				t.Errorf("best hand has %d cards, want %d", len(hand.Cards), want)
+			}
+		})
+	}
+}
+
+func TestEvaluatePokerHandInvalidCard(t *testing.T) {
+	for _, dealt := range [][]string{{"1S"}, {"AX", "KS"}, {"K"}} {
+		if _, _, err := evaluatePokerHand(dealt); err == nil {
+			t.Errorf("evaluatePokerHand(%v) accepted an invalid card", dealt)
+		}
+	}
+}
+
+func TestComparePokerHands(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want int
+	}{
+		{"pair kicker", []string{"9S", "9H", "AD", "7C", "3S"}, []string{"9D", "9C", "KD", "QC", "JS"}, 1},
+		{"pair third kicker", []string{"9S", "9H", "AD", "7C", "4S"}, []string{"9D", "9C", "AH", "7D", "3S"}, 1},
+		{"two pair kicker", []string{"JS", "JH", "4D", "4C", "2S"}, []string{"JD", "JC", "4H", "4S", "3D"}, -1},
+		{"two pair low pair", []string{"JS", "JH", "5D", "5C", "2S"}, []string{"JD", "JC", "4H", "4S", "AD"}, 1},
+		{"trips kicker", []string{"8S", "8H", "8D", "KC", "3S"}, []string{"8C", "8H", "8D", "QC", "JS"}, 1},
+		{"quads kicker", []string{"7S", "7H", "7D", "7C", "2S"}, []string{"7S", "7H", "7D", "7C", "3S"}, -1},
+		{"full house trips first", []string{"3S", "3H", "3D", "AC", "AS"}, []string{"2S", "2H", "2D", "KC", "KS"}, 1},
+		{"flush fifth card", []string{"KC", "10C", "8C", "4C", "3C"}, []string{"KH", "10H", "8H", "4H", "2H"}, 1},
+		{"wheel loses to six-high straight", []string{"AS", "2H", "3D", "4C", "5S"}, []string{"2S", "3H", "4D", "5C", "6S"}, -1},
+		{"high card kicker", []string{"AS", "JH", "9D", "6C", "4S"}, []string{"AD", "JC", "9H", "6S", "3D"}, 1},
+		{"suits don't break ties", []string{"AS", "KH", "9D", "6C", "4S"}, []string{"AD", "KC", "9H", "6S", "4D"}, 0},
+		{"more cards win on equal ranks", []string{"AS", "KH", "9D"}, []string{"AD", "KC"}, 1},
+		{"hand type beats high cards", []string{"2S", "2H", "3D", "4C", "6S"}, []string{"AS", "KH", "QD", "JC", "9S"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, a, err := evaluatePokerHand(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, b, err := evaluatePokerHand(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := comparePokerKeys(a, b); got != tt.want {
+				t.Errorf("comparePokerKeys(%v, %v) = %d, want %d", a, b, got, tt.want)
+			}
+			if got := comparePokerKeys(b, a); got != -tt.want {
+				t.Errorf("comparePokerKeys(%v, %v) = %d, want %d", b, a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestPokerSeedCommitment(t *testing.T) {
+	seed, seedHash, err := generatePokerSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seed) != pokerSeedBytes*2 {
+		t.Errorf("seed has %d hex digits, want %d", len(seed), pokerSeedBytes*2)
+	}
+	if hashPokerSeed(seed) != seedHash {
+		t.Errorf("published hash %s does not match the seed", seedHash)
+	}
+
+	other, _, err := generatePokerSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == seed {
+		t.Error("two games were given the same seed")
+	}
+}
+
+func TestShuffleDeck(t *testing.T) {
+	const seed = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		name        string
+		cardsNeeded int
+		decks       int
+	}{
+		{"no cards", 0, 1},
+		{"one deck", 20, 1},
+		{"full deck", 52, 1},
+		{"two decks", 53, 2},
+		{"three decks", 120, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deck, err := shuffleDeck(seed, tt.cardsNeeded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(deck) != tt.decks*52 {
+				t.Fatalf("deck has %d cards, want %d", len(deck), tt.decks*52)
+			}
+
+			counts := map[string]int{}
+			for _, card := range deck {
+				counts[card]++
+			}
+			if len(counts) != 52 {
+				t.Errorf("deck has %d distinct cards, want 52", len(counts))
+			}
+			for card, count := range counts {
+				if count != tt.decks {
+					t.Errorf("%s appears %d times, want %d", card, count, tt.decks)
+				}
+			}
+
+			again, err := shuffleDeck(seed, tt.cardsNeeded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(deck, again) {
+				t.Error("shuffling the same seed twice gave different decks")
+			}
+		})
+	}
+
+	other, err := shuffleDeck("00"+seed[2:], 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck, _ := shuffleDeck(seed, 20)
+	if reflect.DeepEqual(deck, other) {
+		t.Error("different seeds gave the same deck")
+	}
+
+	if _, err := shuffleDeck("not hex", 5); err == nil {
+		t.Error("shuffleDeck accepted a seed that isn't hex")
+	}
+}
+
+func TestPokerDealReproducibleFromSeed(t *testing.T) {
+	poker, gameID, playerIDs := completedPokerGame(t)
+
+	deal, err := poker.DealPokerCards(gameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := poker.GetPokerDeal(gameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stored.RandomSeed != deal.RandomSeed || hashPokerSeed(stored.RandomSeed) != stored.SeedHash {
+		t.Fatalf("revealed seed %s does not match its commitment %s", stored.RandomSeed, stored.SeedHash)
+	}
+
+	// Re-deal from the revealed seed in tee order
+	dealt := make(map[string][]string, len(stored.Players))
+	total := 0
+	for _, hand := range stored.Players {
+		dealt[hand.Player.ID] = hand.DealtCards
+		total += hand.TotalCardsEarned
+	}
+	deck, err := shuffleDeck(stored.RandomSeed, total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offset := 0
+	for _, playerID := range playerIDs {
+		count := len(dealt[playerID])
+		if !reflect.DeepEqual(deck[offset:offset+count], dealt[playerID]) {
+			t.Errorf("player %s was dealt %v, the seed deals %v", playerID, dealt[playerID], deck[offset:offset+count])
+		}
+		offset += count
+	}
+	if offset != total {
+		t.Errorf("dealt %d cards, players earned %d", offset, total)
+	}
+
+	// Positions follow the evaluated hands
+	keys := make(map[string][]int, len(stored.Players))
+	for _, hand := range stored.Players {
+		_, key, err := evaluatePokerHand(hand.DealtCards)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[hand.Player.ID] = key
+	}
+	hands := append([]models.PokerHand{}, stored.Players...)
+	sort.SliceStable(hands, func(i, j int) bool { return hands[i].Position < hands[j].Position })
+	for i := 1; i < len(hands); i++ {
+		if comparePokerKeys(keys[hands[i-1].Player.ID], keys[hands[i].Player.ID]) < 0 {
+			t.Errorf("%s is placed ahead of %s with a worse hand", hands[i-1].Player.Name, hands[i].Player.Name)
+		}
+	}
+}
+
+// completedPokerGame plays a four-player Putt Putt Poker game to completion,
+// ready for the deal, and returns the players in tee order
+func completedPokerGame(t *testing.T) (*PuttPuttPokerBet, string, []string) {
+	t.Helper()
+	db := newTestDB(t)
+
+	poker := NewPuttPuttPokerBet(db, PokerStakes{BuyIn: 5, PenaltyAmount: 1})
+	registry := NewSideBetRegistry(poker)
+	games := NewGameService(db, registry)
+	sideBets := NewSideBetService(db, registry)
+	players := NewPlayerService(db)
+	scores := NewScoreService(db, sideBets)
+
+	game, err := games.CreateGame(&models.CreateGameRequest{
+		Course:   "diamond-run",
+		SideBets: []models.SideBetType{models.SideBetPuttPuttPoker},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var playerIDs []string
+	for i, name := range []string{"Ann", "Bob", "Cat", "Dan"} {
+		handicap := float64(i * 5)
+		player, err := players.AddPlayer(game.ID, &models.CreatePlayerRequest{Name: name, Handicap: &handicap})
+		if err != nil {
+			t.Fatal(err)
+		}
+		playerIDs = append(playerIDs, player.ID)
+	}
+
+	if _, err := games.StartGame(game.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for hole := 1; hole <= roundHoles; hole++ {
+		for i, playerID := range playerIDs {
+			// One-putts earn cards, so each player ends with a different hand size
+			putts := 2
+			if (hole+i)%(i+2) == 0 {
+				putts = 1
+			}
+			score, err := scores.RecordScore(game.ID, playerID, &models.ScoreRequest{Hole: hole, Strokes: 5, Putts: putts})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := sideBets.UpdateSideBetsForScore(game.ID, playerID, score); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if _, err := games.CompleteGame(game.ID); err != nil {
+		t.Fatal(err)
+	}
+	return poker, game.ID, playerIDs
+}
+
+func TestPokerDealOnlyOnce(t *testing.T) {
+	poker, gameID, _ := completedPokerGame(t)
+
+	// Deal several times at once; exactly one deal may succeed
+	const deals = 8
+	results := make(chan error, deals)
+	for i := 0; i < deals; i++ {
+		go func() {
+			_, err := poker.DealPokerCards(gameID)
+			results <- err
+		}()
+	}
+
+	dealt := 0
+	for i := 0; i < deals; i++ {
+		err := <-results
+		if err == nil {
+			dealt++
+			continue
+		}
+		if apiErr, ok := err.(*errors.APIError); !ok || apiErr.Code != errors.ErrCardsAlreadyDealt {
+			t.Errorf("repeated deal failed with %v, want %s", err, errors.ErrCardsAlreadyDealt)
+		}
+	}
+	if dealt != 1 {
+		t.Errorf("%d deals succeeded, want 1", dealt)
+	}
+
+	var hands int
+	if err := poker.db.QueryRow("SELECT COUNT(*) FROM poker_hands WHERE game_id = ?", gameID).Scan(&hands); err != nil {
+		t.Fatal(err)
+	}
+	if hands != 4 {
+		t.Errorf("stored %d hands, want 4", hands)
+	}
+
+	if _, err := poker.DealPokerCards(gameID); err == nil {
+		t.Error("dealing again after the deal succeeded")
+	}
+}
@@ -0,0 +1,518 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+const (
+	// maxSimulations is the most rounds a projection may simulate
+	maxSimulations = 10000
+	// minHistoryHoles is how many holes a player needs to have recorded
+	// before their simulated holes are drawn from their own scores alone
+	minHistoryHoles = 6
+	// maxHoleStrokes is the most strokes that can be recorded on a hole
+	maxHoleStrokes = 20
+	// maxHistoryRounds is how many of a tracked golfer's most recent
+	// completed rounds their simulated holes are also drawn from
+	maxHistoryRounds = 20
+)
+
+// SideBetProjector is implemented by side bets decided by the strokes each
+// player takes, so they can be played forward over the remaining holes.
+// Projection returns nil when the bet can't be projected.
+type SideBetProjector interface {
+	Projection(gameID string, rounds []*projectedRound) (*roundProjection, error)
+}
+
+// projectedRound is a player's round with the holes they have still to play
+type projectedRound struct {
+	*playerRound
+	remaining []int
+	pars      map[int]int // Par of each hole on the player's card
+	received  map[int]int // Handicap strokes due on the remaining holes
+	past      []int       // Scores to par from the golfer's earlier rounds
+}
+
+// roundProjection plays one result forward. Score returns each contesting
+// player's result from their cards, in the order of rounds. Level returns the
+// strokes over par a player plays a hole in to par it in, which for net
+// results includes their handicap strokes; nil means par.
+type roundProjection struct {
+	result   string
+	rounds   []*projectedRound
+	highWins bool
+	score    func(cards []map[int]roundHole) []float64
+	format   func(value float64) string
+	level    func(round *projectedRound, hole int) int
+}
+
+// GetProjections works out the most each player can take over their remaining
+// holes and still win or tie the main round and each side bet that can be
+// projected, assuming everyone else plays their remaining holes level: in par,
+// or in net par for results played with handicaps. With simulations, the rest
+// of the round is also played forward that many times from each player's
+// scores to estimate their chance of winning.
+func (s *ScoreService) GetProjections(gameID string, simulations int) (*models.Projections, error) {
+	if simulations < 0 || simulations > maxSimulations {
+		return nil, errors.ValidationError("simulations", fmt.Sprintf("%d", simulations), fmt.Sprintf("must be between 0 and %d", maxSimulations))
+	}
+
+	// Project from the same scores the side bets were updated for
+	defer s.locks.rlock(gameID)()
+
+	var teamFormat sql.NullString
+	err := s.db.QueryRow("SELECT team_format FROM games WHERE id = ?", gameID).Scan(&teamFormat)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Game", gameID)
+		}
+		return nil, err
+	}
+
+	// Scramble teams play one ball, so there are no individual scores
+	if teamFormat.String == string(models.TeamScramble) {
+		return nil, errors.New(errors.ErrInvalidGameState, "Scramble games have no individual scores to project")
+	}
+
+	scoring, err := s.getGameScoring(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds, err := s.getProjectedRounds(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	projections := overallProjections(scoring, rounds)
+	if s.sideBets != nil {
+		game, err := s.sideBets.getGameInfo(gameID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sideBetType := range game.SideBets {
+			bet, ok := s.sideBets.registry.Get(sideBetType)
+			if !ok {
+				continue
+			}
+			projector, ok := bet.(SideBetProjector)
+			if !ok {
+				continue
+			}
+			projection, err := projector.Projection(gameID, rounds)
+			if err != nil {
+				return nil, fmt.Errorf("failed to project %s: %w", sideBetType, err)
+			}
+			if projection != nil {
+				projections = append(projections, projection)
+			}
+		}
+	}
+
+	result := &models.Projections{
+		GameID:      gameID,
+		Simulations: simulations,
+		Results:     make([]models.Projection, 0, len(projections)),
+	}
+	for _, projection := range projections {
+		result.Results = append(result.Results, projection.targets())
+	}
+
+	if simulations > 0 {
+		simulateRounds(rounds, projections, result.Results, simulations)
+	}
+
+	return result, nil
+}
+
+// overallProjections returns the main round results that can be projected.
+// Match play is decided hole by hole against the other side, so it has none.
+func overallProjections(scoring *gameScoring, rounds []*projectedRound) []*roundProjection {
+	toPar := func(value float64) string {
+		return models.FormatScoreToPar(int(value), 0)
+	}
+	netLevel := func(round *projectedRound, hole int) int {
+		return allocatedStrokes(roundedHandicap(&round.handicap), scoring.ranking(round.player.ID, hole))
+	}
+
+	switch {
+	case scoring.Format == models.ScoringMatchPlay:
+		return nil
+	case scoring.Format.IsStableford():
+		stableford := &roundProjection{
+			result:   models.PlayoffOverall,
+			rounds:   rounds,
+			highWins: true,
+			score: func(cards []map[int]roundHole) []float64 {
+				points := make([]float64, len(cards))
+				for i, card := range cards {
+					for hole, score := range card {
+						points[i] += float64(scoring.holePoints(rounds[i].player.ID, hole, score.strokes, score.par, rounds[i].handicap))
+					}
+				}
+				return points
+			},
+			format: func(value float64) string {
+				return formatStablefordPoints(int(value))
+			},
+		}
+		if scoring.HandicapEnabled {
+			stableford.level = netLevel
+		}
+		return []*roundProjection{stableford}
+	}
+
+	gross := &roundProjection{
+		result: models.PlayoffOverall,
+		rounds: rounds,
+		score: func(cards []map[int]roundHole) []float64 {
+			totals := make([]float64, len(cards))
+			for i, card := range cards {
+				for _, score := range card {
+					totals[i] += float64(score.strokes - score.par)
+				}
+			}
+			return totals
+		},
+		format: toPar,
+	}
+	if !scoring.HandicapEnabled {
+		return []*roundProjection{gross}
+	}
+
+	net := &roundProjection{
+		result: models.PlayoffNet,
+		rounds: rounds,
+		score: func(cards []map[int]roundHole) []float64 {
+			totals := make([]float64, len(cards))
+			for i, card := range cards {
+				handicap := roundedHandicap(&rounds[i].handicap)
+				for hole, score := range card {
+					totals[i] += float64(score.strokes - score.par - allocatedStrokes(handicap, scoring.ranking(rounds[i].player.ID, hole)))
+				}
+			}
+			return totals
+		},
+		format: toPar,
+		level:  netLevel,
+	}
+	return []*roundProjection{gross, net}
+}
+
+// getProjectedRounds loads every player's round with the holes still to play
+func (s *ScoreService) getProjectedRounds(gameID string) ([]*projectedRound, error) {
+	rounds, err := s.getPlayerRounds(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := (&sideBetStore{db: s.db}).getPlayerCourses(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	past, err := s.getPastHoles(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	projected := make([]*projectedRound, 0, len(rounds))
+	for _, round := range rounds {
+		received, err := s.getHandicapStrokes(gameID, round.player.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		p := &projectedRound{
+			playerRound: round,
+			pars:        courses[round.player.ID].pars(),
+			received:    make(map[int]int),
+			past:        past[round.player.ID],
+		}
+		for hole := 1; hole <= roundHoles; hole++ {
+			if _, ok := round.holes[hole]; ok {
+				continue
+			}
+			p.remaining = append(p.remaining, hole)
+			p.received[hole] = received[hole]
+		}
+		projected = append(projected, p)
+	}
+
+	return projected, nil
+}
+
+// getPastHoles loads the scores to par of each tracked golfer's holes from
+// their most recent completed games, keyed by player ID. Players who aren't
+// linked to a golfer have none.
+func (s *ScoreService) getPastHoles(gameID string) (map[string][]int, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, past.game_id, sc.strokes - sc.par
+		FROM players p
+		JOIN players past ON past.golfer_id = p.golfer_id AND past.game_id != p.game_id
+		JOIN games g ON g.id = past.game_id
+		JOIN scores sc ON sc.player_id = past.id
+		WHERE p.game_id = ? AND p.golfer_id IS NOT NULL AND g.status = ?
+		ORDER BY p.id, g.completed_at DESC, past.game_id, sc.hole
+	`, gameID, models.GameStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	past := make(map[string][]int)
+	games := make(map[string]map[string]bool)
+	for rows.Next() {
+		var playerID, pastGameID string
+		var toPar int
+		if err := rows.Scan(&playerID, &pastGameID, &toPar); err != nil {
+			return nil, err
+		}
+
+		if games[playerID] == nil {
+			games[playerID] = make(map[string]bool)
+		}
+		if !games[playerID][pastGameID] {
+			if len(games[playerID]) == maxHistoryRounds {
+				continue
+			}
+			games[playerID][pastGameID] = true
+		}
+		past[playerID] = append(past[playerID], toPar)
+	}
+
+	return past, rows.Err()
+}
+
+// sideBetRounds picks out the rounds of a side bet's players
+func sideBetRounds(rounds []*projectedRound, players []models.PlayerSummary) []*projectedRound {
+	playing := make(map[string]bool, len(players))
+	for _, player := range players {
+		playing[player.ID] = true
+	}
+
+	picked := make([]*projectedRound, 0, len(players))
+	for _, round := range rounds {
+		if playing[round.player.ID] {
+			picked = append(picked, round)
+		}
+	}
+	return picked
+}
+
+// card returns a round's recorded holes with the remaining holes filled in.
+// toPar gives the score to par for each remaining hole in turn.
+func (r *projectedRound) card(toPar func(i int) int) map[int]roundHole {
+	card := make(map[int]roundHole, roundHoles)
+	for hole, score := range r.holes {
+		card[hole] = score
+	}
+
+	for i, hole := range r.remaining {
+		strokes := r.pars[hole] + toPar(i)
+		if strokes < 1 {
+			strokes = 1
+		}
+		if strokes > maxHoleStrokes {
+			strokes = maxHoleStrokes
+		}
+		card[hole] = roundHole{strokes: strokes, par: r.pars[hole], handicapStrokes: r.received[hole]}
+	}
+
+	return card
+}
+
+// history returns the scores to par a player's simulated holes are drawn
+// from: their recorded holes and, for a tracked golfer, the holes of their
+// recent rounds. Players without enough holes of their own are topped up
+// with synthetic holes played to their handicap.
+func (r *projectedRound) history() []int {
+	history := make([]int, 0, len(r.holes)+len(r.past))
+	for _, score := range r.holes {
+		history = append(history, score.strokes-score.par)
+	}
+	history = append(history, r.past...)
+	if len(history) >= minHistoryHoles {
+		return history
+	}
+
+	handicap := roundedHandicap(&r.handicap)
+	for ranking := 1; ranking <= roundHoles; ranking++ {
+		allocated := allocatedStrokes(handicap, ranking)
+		history = append(history, allocated-1, allocated, allocated, allocated+1)
+	}
+	return history
+}
+
+// evaluate scores the contesting players' cards, keyed by player ID
+func (p *roundProjection) evaluate(cards map[string]map[int]roundHole) []float64 {
+	ordered := make([]map[int]roundHole, len(p.rounds))
+	for i, round := range p.rounds {
+		ordered[i] = cards[round.player.ID]
+	}
+	return p.score(ordered)
+}
+
+// compare reports whether a player's score beats or equals everyone else's
+func (p *roundProjection) compare(scores []float64, player int) (wins, ties bool) {
+	wins, ties = true, true
+	for i, score := range scores {
+		if i == player {
+			continue
+		}
+		if p.highWins {
+			wins = wins && scores[player] > score
+			ties = ties && scores[player] >= score
+		} else {
+			wins = wins && scores[player] < score
+			ties = ties && scores[player] <= score
+		}
+	}
+	return wins, ties
+}
+
+// targets works out what each player needs to win or tie the result
+func (p *roundProjection) targets() models.Projection {
+	recorded := make(map[string]map[int]roundHole, len(p.rounds))
+	parIn := make(map[string]map[int]roundHole, len(p.rounds))
+	for _, round := range p.rounds {
+		recorded[round.player.ID] = round.holes
+		parIn[round.player.ID] = round.card(func(i int) int {
+			if p.level == nil {
+				return 0
+			}
+			return p.level(round, round.remaining[i])
+		})
+	}
+	current := p.evaluate(recorded)
+
+	projection := models.Projection{
+		Result:  p.result,
+		Players: make([]models.PlayerProjection, 0, len(p.rounds)),
+	}
+	for i, round := range p.rounds {
+		entry := models.PlayerProjection{
+			Player:         round.player,
+			Current:        p.format(current[i]),
+			HolesRemaining: len(round.remaining),
+			Status:         models.ProjectionFinished,
+		}
+		if len(round.remaining) > 0 {
+			entry.ToWin, entry.ToTie = p.target(i, parIn)
+			entry.Status = models.ProjectionInContention
+			if entry.ToTie == nil {
+				entry.Status = models.ProjectionOutOfReach
+			}
+		}
+		projection.Players = append(projection.Players, entry)
+	}
+
+	return projection
+}
+
+// target finds the most strokes a player can take over their remaining holes,
+// spread evenly across them, and still win or tie while everyone else plays
+// their remaining holes level
+func (p *roundProjection) target(player int, parIn map[string]map[int]roundHole) (win, tie *models.ProjectionTarget) {
+	round := p.rounds[player]
+	holes := len(round.remaining)
+	par := 0
+	for _, hole := range round.remaining {
+		par += round.pars[hole]
+	}
+
+	cards := make(map[string]map[int]roundHole, len(parIn))
+	for id, card := range parIn {
+		cards[id] = card
+	}
+
+	winning := true
+	most := holes*maxHoleStrokes - par
+	for over := holes - par; over <= most; over++ {
+		cards[round.player.ID] = round.card(spreadStrokes(over, holes))
+		wins, ties := p.compare(p.evaluate(cards), player)
+		if !ties {
+			break
+		}
+
+		reached := &models.ProjectionTarget{
+			Strokes:    par + over,
+			ScoreToPar: models.FormatScoreToPar(over, 0),
+			AnyScore:   over == most,
+		}
+		tie = reached
+		winning = winning && wins
+		if winning {
+			win = reached
+		}
+	}
+
+	return win, tie
+}
+
+// spreadStrokes spreads strokes over par as evenly as possible across a
+// number of holes, giving any extra strokes to the last holes
+func spreadStrokes(over, holes int) func(i int) int {
+	base := int(math.Floor(float64(over) / float64(holes)))
+	extra := over - base*holes
+	return func(i int) int {
+		if i >= holes-extra {
+			return base + 1
+		}
+		return base
+	}
+}
+
+// simulateRounds plays every player's remaining holes forward the given
+// number of times and records each player's share of the wins of each
+// result. Players tied for a win share it.
+func simulateRounds(rounds []*projectedRound, projections []*roundProjection, results []models.Projection, simulations int) {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	histories := make(map[string][]int, len(rounds))
+	for _, round := range rounds {
+		histories[round.player.ID] = round.history()
+	}
+
+	wins := make([][]float64, len(projections))
+	for i, projection := range projections {
+		wins[i] = make([]float64, len(projection.rounds))
+	}
+
+	cards := make(map[string]map[int]roundHole, len(rounds))
+	for n := 0; n < simulations; n++ {
+		for _, round := range rounds {
+			history := histories[round.player.ID]
+			cards[round.player.ID] = round.card(func(int) int {
+				return history[random.Intn(len(history))]
+			})
+		}
+
+		for i, projection := range projections {
+			scores := projection.evaluate(cards)
+			var leaders []int
+			for player := range scores {
+				if _, ties := projection.compare(scores, player); ties {
+					leaders = append(leaders, player)
+				}
+			}
+			for _, leader := range leaders {
+				wins[i][leader] += 1 / float64(len(leaders))
+			}
+		}
+	}
+
+	for i := range projections {
+		for player := range results[i].Players {
+			probability := math.Round(wins[i][player]/float64(simulations)*1000) / 1000
+			results[i].Players[player].WinProbability = &probability
+		}
+	}
+}
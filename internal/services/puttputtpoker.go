@@ -0,0 +1,979 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/auth"
+	"golf-gamez/pkg/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// pokerStartingCards is the number of cards every player starts with
+	pokerStartingCards = 3
+)
+
+// PokerStakes holds the dollar amounts used for Putt Putt Poker pots
+type PokerStakes struct {
+	BuyIn         float64 // Per-player base bet
+	PenaltyAmount float64 // Added to the pot for each three-putt or worse
+}
+
+// PuttPuttPokerBet awards poker cards for good putting and deals a final
+// hand to each player once the round is complete
+type PuttPuttPokerBet struct {
+	sideBetStore
+	stakes PokerStakes
+}
+
+// NewPuttPuttPokerBet creates the Putt Putt Poker side bet
+func NewPuttPuttPokerBet(db *sql.DB, stakes PokerStakes) *PuttPuttPokerBet {
+	return &PuttPuttPokerBet{
+		sideBetStore: sideBetStore{db: db},
+		stakes:       stakes,
+	}
+}
+
+// Type returns the Putt Putt Poker side bet type
+func (s *PuttPuttPokerBet) Type() models.SideBetType {
+	return models.SideBetPuttPuttPoker
+}
+
+// DefaultStake returns the configured buy-in and penalty, with the best hand taking the pot
+func (s *PuttPuttPokerBet) DefaultStake() models.BetStake {
+	buyIn := s.stakes.BuyIn
+	penalty := s.stakes.PenaltyAmount
+	return models.BetStake{BuyIn: &buyIn, Penalty: &penalty, PayoutSplit: []int{100}}
+}
+
+// InitializePlayer commits the deal seed and starts the player's card ledger
+func (s *PuttPuttPokerBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	// Commit to the deal seed before any cards are earned
+	if _, err := commitPokerSeed(s.db, gameID); err != nil {
+		return nil, err
+	}
+
+	cardID, err := auth.GeneratePokerCardID()
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.db.Exec(`
+		INSERT INTO putt_putt_poker_cards
+		(id, player_id, game_id, action, cards_change, total_cards)
+		VALUES (?, ?, ?, 'starting', ?, ?)
+	`, cardID, player.ID, gameID, pokerStartingCards, pokerStartingCards)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.PuttPuttPokerCalculationData{
+		TotalCards:   pokerStartingCards,
+		PuttingStats: models.PuttingStats{},
+		CardHistory:  []models.CardEvent{},
+	}, nil
+}
+
+// ApplyScore updates the player's card ledger for a recorded hole, if they
+// play Putt Putt Poker
+func (s *PuttPuttPokerBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	playing, err := s.playsSideBet(playerID, models.SideBetPuttPuttPoker)
+	if err != nil || !playing {
+		return err
+	}
+
+	update, err := s.updatePuttPuttPokerForScore(gameID, playerID, score)
+	if err != nil {
+		return err
+	}
+	updates.PuttPuttPoker = update
+	return nil
+}
+
+// Recalculate refreshes the stored card counts and positions
+func (s *PuttPuttPokerBet) Recalculate(gameID string) error {
+	results, calculations, _, err := s.calculatePuttPuttPoker(gameID)
+	if err != nil {
+		return err
+	}
+	return s.savePuttPuttPokerCalculations(gameID, results, calculations)
+}
+
+// Finalize adds the poker winner to the final results when the final hands
+// have been dealt. Hands can only be dealt after completion, so the deal
+// itself normally records the winner via recordPokerWinner.
+func (s *PuttPuttPokerBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	winner, err := s.getPokerWinner(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if winner != nil {
+		results.PuttPuttPokerWinner = winner
+	}
+	return nil, nil
+}
+
+// Settle charges each player the buy-in plus their three-putt penalties and
+// splits the pot by the final hands, tied hands sharing the places they
+// fill. The pot can't be settled until the final hands are dealt.
+func (s *PuttPuttPokerBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	rows, err := s.db.Query(`
+		SELECT player_id, position
+		FROM poker_hands
+		WHERE game_id = ?
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	handPositions := make(map[string]int)
+	for rows.Next() {
+		var playerID string
+		var position int
+		if err := rows.Scan(&playerID, &position); err != nil {
+			return nil, err
+		}
+		handPositions[playerID] = position
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(handPositions) == 0 {
+		return nil, nil
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetPuttPuttPoker)
+	if err != nil {
+		return nil, err
+	}
+
+	stake, err := s.getBetStake(gameID, models.SideBetPuttPuttPoker, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	paidIn := make([]float64, len(players))
+	positions := make([]int, len(players))
+	pot := 0.0
+	for i, player := range players {
+		_, penalties, err := s.getPokerCardHistory(player.ID)
+		if err != nil {
+			return nil, err
+		}
+		paidIn[i] = stake.buyIn + penalties
+		positions[i] = handPositions[player.ID]
+		pot += paidIn[i]
+	}
+	shares := payoutShares(pot, stake.payoutSplit, positions)
+
+	amounts := make([]models.SettlementAmount, 0, len(players))
+	for i, player := range players {
+		amounts = append(amounts, models.SettlementAmount{Player: player, Amount: roundCurrency(shares[i] - paidIn[i])})
+	}
+	return amounts, nil
+}
+
+// Actions exposes the final deal endpoints
+func (s *PuttPuttPokerBet) Actions() []SideBetAction {
+	return []SideBetAction{
+		{
+			Method: http.MethodGet,
+			Path:   "/deal",
+			Handle: func(gameID string, body []byte) (interface{}, error) {
+				return s.GetPokerDeal(gameID)
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Path:   "/deal",
+			Status: http.StatusCreated,
+			Handle: func(gameID string, body []byte) (interface{}, error) {
+				return s.DealPokerCards(gameID)
+			},
+		},
+	}
+}
+
+// Standings returns the Putt Putt Poker side bet status
+func (s *PuttPuttPokerBet) Standings(gameID string) (interface{}, error) {
+	// Verify game exists and has Putt Putt Poker enabled
+	game, err := s.getGameForSideBet(gameID, models.SideBetPuttPuttPoker)
+	if err != nil {
+		return nil, err
+	}
+
+	results, _, potInfo, err := s.calculatePuttPuttPoker(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate putt putt poker: %w", err)
+	}
+
+	status := &models.PuttPuttPokerStatus{
+		BetType:     models.SideBetPuttPuttPoker,
+		Status:      game.Status,
+		CurrentHole: game.CurrentHole,
+		Players:     results,
+		PotInfo:     potInfo,
+	}
+
+	// Publish the seed commitment; the seed itself stays secret until the deal
+	var seedHash string
+	err = s.db.QueryRow("SELECT seed_hash FROM poker_seeds WHERE game_id = ?", gameID).Scan(&seedHash)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	status.SeedHash = seedHash
+
+	return status, nil
+}
+
+// DealPokerCards deals final poker cards and determines winner
+func (s *PuttPuttPokerBet) DealPokerCards(gameID string) (*models.PokerDealResult, error) {
+	// Verify game is completed and has Putt Putt Poker enabled
+	game, err := s.getGameForSideBet(gameID, models.SideBetPuttPuttPoker)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != models.GameStatusCompleted {
+		return nil, errors.BusinessLogicError(
+			errors.ErrGameNotCompleted,
+			"Cannot deal final cards until game is completed",
+			string(game.Status),
+			string(models.GameStatusCompleted),
+		)
+	}
+
+	// Check if cards have already been dealt
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM poker_hands WHERE game_id = ?", gameID).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > 0 {
+		return nil, pokerAlreadyDealtError()
+	}
+
+	_, calculations, potInfo, err := s.calculatePuttPuttPoker(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate putt putt poker: %w", err)
+	}
+
+	seed, seedHash, err := s.getPokerSeed(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load poker seed: %w", err)
+	}
+
+	// Deal in tee order so the deal can be reproduced from the seed
+	players, err := s.getSideBetPlayers(gameID, models.SideBetPuttPuttPoker)
+	if err != nil {
+		return nil, err
+	}
+
+	cardsNeeded := 0
+	for _, player := range players {
+		cardsNeeded += calculations[player.ID].TotalCards
+	}
+
+	deck, err := shuffleDeck(seed, cardsNeeded)
+	if err != nil {
+		return nil, err
+	}
+
+	hands := make([]models.PokerHand, 0, len(players))
+	keys := make(map[string][]int, len(players))
+	offset := 0
+	for _, player := range players {
+		count := calculations[player.ID].TotalCards
+		dealt := append([]string{}, deck[offset:offset+count]...)
+		offset += count
+
+		bestHand, key, err := evaluatePokerHand(dealt)
+		if err != nil {
+			return nil, err
+		}
+		keys[player.ID] = key
+
+		hands = append(hands, models.PokerHand{
+			Player:           models.PlayerSummary{ID: player.ID, Name: player.Name},
+			TotalCardsEarned: count,
+			DealtCards:       dealt,
+			BestHand:         bestHand,
+		})
+	}
+
+	sort.SliceStable(hands, func(i, j int) bool {
+		return comparePokerKeys(keys[hands[i].Player.ID], keys[hands[j].Player.ID]) > 0
+	})
+	for i := range hands {
+		if i > 0 && comparePokerKeys(keys[hands[i].Player.ID], keys[hands[i-1].Player.ID]) == 0 {
+			hands[i].Position = hands[i-1].Position
+			continue
+		}
+		hands[i].Position = i + 1
+	}
+
+	dealTimestamp := time.Now()
+	if err := s.savePokerDeal(gameID, hands, seed, dealTimestamp); err != nil {
+		if _, ok := err.(*errors.APIError); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save poker deal: %w", err)
+	}
+
+	for _, hand := range hands {
+		err := s.saveSideBetCalculation(s.db, gameID, sideBetCalculation{
+			playerID: hand.Player.ID,
+			betType:  models.SideBetPuttPuttPoker,
+			data:     calculations[hand.Player.ID],
+			position: hand.Position,
+			final:    true,
+			isWinner: hand.Position == 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save putt putt poker results: %w", err)
+		}
+	}
+
+	if err := s.recordPokerWinner(gameID); err != nil {
+		return nil, fmt.Errorf("failed to record putt putt poker winner: %w", err)
+	}
+
+	// The pot can be settled now the hands are dealt
+	if err := s.resettleBet(gameID, models.SideBetPuttPuttPoker, s); err != nil {
+		return nil, fmt.Errorf("failed to settle putt putt poker: %w", err)
+	}
+
+	log.Info().
+		Str("game_id", gameID).
+		Str("seed_hash", seedHash).
+		Int("cards_dealt", cardsNeeded).
+		Msg("Poker cards dealt")
+
+	return buildPokerDealResult(hands, seed, seedHash, dealTimestamp, potInfo), nil
+}
+
+// GetPokerDeal returns the stored result of a game's final poker deal,
+// including the revealed seed so the shuffle can be verified
+func (s *PuttPuttPokerBet) GetPokerDeal(gameID string) (*models.PokerDealResult, error) {
+	if _, err := s.getGameForSideBet(gameID, models.SideBetPuttPuttPoker); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT h.player_id, p.name, h.total_cards_earned, h.dealt_cards,
+		       h.best_hand_cards, h.hand_type, h.hand_rank, h.hand_description,
+		       h.position, h.deal_timestamp, h.random_seed
+		FROM poker_hands h
+		JOIN players p ON p.id = h.player_id
+		WHERE h.game_id = ?
+		ORDER BY h.position, p.position
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hands []models.PokerHand
+	var dealTimestamp time.Time
+	var seed string
+
+	for rows.Next() {
+		var hand models.PokerHand
+		var dealtJSON, bestJSON string
+		var description sql.NullString
+
+		err := rows.Scan(
+			&hand.Player.ID,
+			&hand.Player.Name,
+			&hand.TotalCardsEarned,
+			&dealtJSON,
+			&bestJSON,
+			&hand.BestHand.HandType,
+			&hand.BestHand.HandRank,
+			&description,
+			&hand.Position,
+			&dealTimestamp,
+			&seed,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(dealtJSON), &hand.DealtCards); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(bestJSON), &hand.BestHand.Cards); err != nil {
+			return nil, err
+		}
+		hand.BestHand.Description = description.String
+
+		hands = append(hands, hand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(hands) == 0 {
+		return nil, errors.ResourceNotFoundError("Poker deal", gameID)
+	}
+
+	_, _, potInfo, err := s.calculatePuttPuttPoker(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPokerDealResult(hands, seed, hashPokerSeed(seed), dealTimestamp, potInfo), nil
+}
+
+// updatePuttPuttPokerForScore brings a player's card ledger in line with the
+// putts recorded for a hole. When a scored hole is edited, the previous award
+// is reversed before the new one is applied so the ledger never double counts.
+func (s *PuttPuttPokerBet) updatePuttPuttPokerForScore(gameID, playerID string, score *models.Score) (*models.PuttPuttPokerUpdate, error) {
+	stake, err := s.getBetStake(gameID, models.SideBetPuttPuttPoker, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+	cardsAwarded, penalized := pokerAwardForScore(score.Strokes, score.Putts)
+	penaltyAmount := 0.0
+	if penalized {
+		penaltyAmount = stake.penalty
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Current running total, starting the ledger if the game start didn't
+	var entries, totalCards int
+	err = tx.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(cards_change), 0)
+		FROM putt_putt_poker_cards
+		WHERE player_id = ?
+	`, playerID).Scan(&entries, &totalCards)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries == 0 {
+		totalCards = pokerStartingCards
+		if err := insertPokerCard(tx, gameID, playerID, nil, "starting", pokerStartingCards, nil, totalCards); err != nil {
+			return nil, err
+		}
+	}
+
+	// What the ledger currently credits this hole with. Penalties are
+	// counted as well as summed, since a points-only game charges nothing.
+	var holeEntries, netCards, netPenalties int
+	var netPenalty float64
+	err = tx.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(cards_change), 0), COALESCE(SUM(penalty_amount), 0),
+		       COALESCE(SUM(CASE
+		           WHEN action = 'penalty' THEN 1
+		           WHEN action = 'reversal' AND penalty_amount IS NOT NULL THEN -1
+		           ELSE 0
+		       END), 0)
+		FROM putt_putt_poker_cards
+		WHERE player_id = ? AND hole = ?
+	`, playerID, score.Hole).Scan(&holeEntries, &netCards, &netPenalty, &netPenalties)
+	if err != nil {
+		return nil, err
+	}
+
+	penalties := 0
+	if penalized {
+		penalties = 1
+	}
+	unchanged := netCards == cardsAwarded && netPenalties == penalties && math.Abs(netPenalty-penaltyAmount) < 0.005
+	if !unchanged {
+		hole := score.Hole
+
+		if netCards != 0 || netPenalties != 0 {
+			totalCards -= netCards
+			var reversedPenalty *float64
+			if netPenalties != 0 {
+				amount := -netPenalty
+				reversedPenalty = &amount
+			}
+			if err := insertPokerCard(tx, gameID, playerID, &hole, "reversal", -netCards, reversedPenalty, totalCards); err != nil {
+				return nil, err
+			}
+		}
+
+		if cardsAwarded > 0 {
+			action := "one_putt"
+			if score.Strokes == 1 {
+				action = "hole_in_one"
+			}
+			totalCards += cardsAwarded
+			if err := insertPokerCard(tx, gameID, playerID, &hole, action, cardsAwarded, nil, totalCards); err != nil {
+				return nil, err
+			}
+		}
+
+		if penalized {
+			amount := penaltyAmount
+			if err := insertPokerCard(tx, gameID, playerID, &hole, "penalty", 0, &amount, totalCards); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	// Refresh stored calculations and positions for every player
+	results, calculations, _, err := s.calculatePuttPuttPoker(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.savePuttPuttPokerCalculations(gameID, results, calculations); err != nil {
+		return nil, err
+	}
+
+	return &models.PuttPuttPokerUpdate{
+		CardsAwarded:   cardsAwarded,
+		PenaltyApplied: penalized,
+		TotalCards:     totalCards,
+	}, nil
+}
+
+// getPokerSeed loads a game's committed deal seed, creating one for games
+// started before seeds were committed at game start
+func (s *PuttPuttPokerBet) getPokerSeed(gameID string) (string, string, error) {
+	if _, err := commitPokerSeed(s.db, gameID); err != nil {
+		return "", "", err
+	}
+
+	var seed, seedHash string
+	err := s.db.QueryRow("SELECT seed, seed_hash FROM poker_seeds WHERE game_id = ?", gameID).Scan(&seed, &seedHash)
+	return seed, seedHash, err
+}
+
+// savePokerDeal marks the seed as revealed and stores every dealt hand.
+// Revealing the seed comes first and only succeeds once, so a concurrent
+// deal waits for this one and then finds the cards already dealt.
+func (s *PuttPuttPokerBet) savePokerDeal(gameID string, hands []models.PokerHand, seed string, dealTimestamp time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE poker_seeds SET revealed_at = ? WHERE game_id = ? AND revealed_at IS NULL", dealTimestamp, gameID)
+	if err != nil {
+		return err
+	}
+	revealed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if revealed == 0 {
+		return pokerAlreadyDealtError()
+	}
+
+	for _, hand := range hands {
+		handID, err := auth.GeneratePokerHandID()
+		if err != nil {
+			return err
+		}
+
+		dealtJSON, err := json.Marshal(hand.DealtCards)
+		if err != nil {
+			return err
+		}
+		bestJSON, err := json.Marshal(hand.BestHand.Cards)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO poker_hands (
+				id, game_id, player_id, total_cards_earned, dealt_cards,
+				best_hand_cards, hand_type, hand_rank, hand_description,
+				position, deal_timestamp, random_seed
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			handID,
+			gameID,
+			hand.Player.ID,
+			hand.TotalCardsEarned,
+			string(dealtJSON),
+			string(bestJSON),
+			hand.BestHand.HandType,
+			hand.BestHand.HandRank,
+			hand.BestHand.Description,
+			hand.Position,
+			dealTimestamp,
+			seed,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// pokerAlreadyDealtError is returned when a game's final cards are dealt twice
+func pokerAlreadyDealtError() error {
+	return errors.New(errors.ErrCardsAlreadyDealt, "Final cards have already been dealt for this game")
+}
+
+// buildPokerDealResult assembles a deal result from ranked hands, splitting
+// the pot by the payout split with tied hands sharing the places they fill
+func buildPokerDealResult(hands []models.PokerHand, seed, seedHash string, dealTimestamp time.Time, potInfo *models.PotInfo) *models.PokerDealResult {
+	result := &models.PokerDealResult{
+		DealTimestamp: dealTimestamp,
+		RandomSeed:    seed,
+		SeedHash:      seedHash,
+		Players:       hands,
+	}
+
+	var winners []models.PokerHand
+	for _, hand := range hands {
+		if hand.Position == 1 {
+			winners = append(winners, hand)
+		}
+	}
+	if len(winners) == 0 {
+		return result
+	}
+
+	result.Winner = &models.PokerWinner{
+		PlayerID:     winners[0].Player.ID,
+		HandType:     string(winners[0].BestHand.HandType),
+		WinningCards: winners[0].BestHand.Cards,
+	}
+	for _, tied := range winners[1:] {
+		result.Winner.TiedWith = append(result.Winner.TiedWith, tied.Player.ID)
+	}
+
+	positions := make([]int, len(hands))
+	for i, hand := range hands {
+		positions[i] = hand.Position
+	}
+	shares := payoutShares(potInfo.TotalPot, potInfo.PayoutSplit, positions)
+
+	result.PotDistribution = &models.PotDistribution{
+		TotalPot: potInfo.TotalPot,
+		Breakdown: &models.PotBreakdown{
+			BaseBets:         potInfo.BaseBet,
+			PenaltyAdditions: potInfo.PenaltyAdditions,
+		},
+	}
+	for i, hand := range hands {
+		if hand.Position == 1 && result.PotDistribution.WinnerTake == 0 {
+			result.PotDistribution.WinnerTake = shares[i]
+		}
+		if shares[i] > 0 {
+			result.PotDistribution.Payouts = append(result.PotDistribution.Payouts, models.PotPayout{
+				Player:   hand.Player,
+				Position: hand.Position,
+				Amount:   shares[i],
+			})
+		}
+	}
+
+	return result
+}
+
+// pokerAwardForScore returns the cards earned for a hole and whether it
+// owes a penalty. A hole-in-one earns two cards, a one-putt earns one and a
+// three-putt or worse adds the penalty amount to the pot.
+func pokerAwardForScore(strokes, putts int) (int, bool) {
+	switch {
+	case strokes == 1:
+		return 2, false
+	case putts == 1:
+		return 1, false
+	case putts >= 3:
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// insertPokerCard appends an entry to the putt putt poker card ledger
+func insertPokerCard(tx *sql.Tx, gameID, playerID string, hole *int, action string, cardsChange int, penaltyAmount *float64, totalCards int) error {
+	cardID, err := auth.GeneratePokerCardID()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO putt_putt_poker_cards
+		(id, player_id, game_id, hole, action, cards_change, penalty_amount, total_cards, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, cardID, playerID, gameID, hole, action, cardsChange, penaltyAmount, totalCards, time.Now())
+	return err
+}
+
+// calculatePuttPuttPoker builds every player's card totals and putting stats
+// from the card ledger and recorded scores. The returned calculations are
+// keyed by player ID.
+func (s *PuttPuttPokerBet) calculatePuttPuttPoker(gameID string) ([]models.PuttPuttPokerResult, map[string]*models.PuttPuttPokerCalculationData, *models.PotInfo, error) {
+	players, err := s.getSideBetPlayers(gameID, models.SideBetPuttPuttPoker)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	results := make([]models.PuttPuttPokerResult, 0, len(players))
+	calculations := make(map[string]*models.PuttPuttPokerCalculationData, len(players))
+	totalPenalties := 0.0
+
+	for _, player := range players {
+		data, penaltyTotal, err := s.getPokerCardHistory(player.ID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		stats, err := s.getPuttingStats(player.ID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		data.PuttingStats = *stats
+
+		calculations[player.ID] = data
+		totalPenalties += penaltyTotal
+
+		results = append(results, models.PuttPuttPokerResult{
+			Player:        models.PlayerSummary{ID: player.ID, Name: player.Name},
+			TotalCards:    data.TotalCards,
+			StartingCards: pokerStartingCards,
+			CardsEarned:   data.CardsEarned,
+			Penalties:     data.Penalties,
+			PuttingStats:  data.PuttingStats,
+		})
+	}
+
+	// Most cards leads; fewer penalties breaks ties
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].TotalCards != results[j].TotalCards {
+			return results[i].TotalCards > results[j].TotalCards
+		}
+		return results[i].Penalties < results[j].Penalties
+	})
+
+	for i := range results {
+		if i > 0 && results[i].TotalCards == results[i-1].TotalCards && results[i].Penalties == results[i-1].Penalties {
+			results[i].Position = results[i-1].Position
+			continue
+		}
+		results[i].Position = i + 1
+	}
+
+	stake, err := s.getBetStake(gameID, models.SideBetPuttPuttPoker, s.DefaultStake())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	baseBet := stake.buyIn * float64(len(players))
+	potInfo := &models.PotInfo{
+		BaseBet:          baseBet,
+		PenaltyAdditions: totalPenalties,
+		TotalPot:         roundCurrency(baseBet + totalPenalties),
+		PayoutSplit:      stake.payoutSplit,
+	}
+
+	return results, calculations, potInfo, nil
+}
+
+// getPokerCardHistory replays a player's card ledger in the order it was
+// written and returns the resulting calculation data and penalty dollars
+func (s *PuttPuttPokerBet) getPokerCardHistory(playerID string) (*models.PuttPuttPokerCalculationData, float64, error) {
+	rows, err := s.db.Query(`
+		SELECT hole, action, cards_change, penalty_amount, total_cards
+		FROM putt_putt_poker_cards
+		WHERE player_id = ?
+		ORDER BY created_at, rowid
+	`, playerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	data := &models.PuttPuttPokerCalculationData{
+		TotalCards:  pokerStartingCards,
+		CardHistory: []models.CardEvent{},
+	}
+	totalCards := 0
+	penaltyTotal := 0.0
+	started := false
+
+	for rows.Next() {
+		var event models.CardEvent
+		var hole sql.NullInt64
+		var penalty sql.NullFloat64
+
+		err := rows.Scan(&hole, &event.Action, &event.CardsChange, &penalty, &event.TotalCards)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if hole.Valid {
+			h := int(hole.Int64)
+			event.Hole = &h
+		}
+		if penalty.Valid {
+			p := penalty.Float64
+			event.PenaltyAmount = &p
+			penaltyTotal += p
+
+			switch {
+			case event.Action == "penalty":
+				data.Penalties++
+			case event.Action == "reversal":
+				data.Penalties--
+			}
+		}
+
+		started = true
+		totalCards += event.CardsChange
+		data.CardHistory = append(data.CardHistory, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if started {
+		data.TotalCards = totalCards
+	}
+	data.CardsEarned = data.TotalCards - pokerStartingCards
+
+	return data, penaltyTotal, nil
+}
+
+// getPuttingStats summarizes a player's putting from their recorded scores
+func (s *PuttPuttPokerBet) getPuttingStats(playerID string) (*models.PuttingStats, error) {
+	var stats models.PuttingStats
+	var holes, totalPutts int
+
+	err := s.db.QueryRow(`
+		SELECT COUNT(*),
+		       COALESCE(SUM(putts), 0),
+		       COALESCE(SUM(CASE WHEN putts = 1 AND strokes > 1 THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN strokes = 1 THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN putts >= 3 THEN 1 ELSE 0 END), 0)
+		FROM scores
+		WHERE player_id = ?
+	`, playerID).Scan(&holes, &totalPutts, &stats.OnePutts, &stats.HoleInOnes, &stats.ThreePutts)
+	if err != nil {
+		return nil, err
+	}
+
+	if holes > 0 {
+		stats.AveragePutts = math.Round(float64(totalPutts)/float64(holes)*100) / 100
+	}
+
+	return &stats, nil
+}
+
+// savePuttPuttPokerCalculations persists Putt Putt Poker results to
+// side_bet_calculations. Once the final hands are dealt the deal's positions
+// and winner stand, so nothing is saved over them.
+func (s *PuttPuttPokerBet) savePuttPuttPokerCalculations(gameID string, results []models.PuttPuttPokerResult, calculations map[string]*models.PuttPuttPokerCalculationData) error {
+	var dealt bool
+	err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM poker_hands WHERE game_id = ?)", gameID).Scan(&dealt)
+	if err != nil || dealt {
+		return err
+	}
+
+	for _, result := range results {
+		err := s.saveSideBetCalculation(s.db, gameID, sideBetCalculation{
+			playerID: result.Player.ID,
+			betType:  models.SideBetPuttPuttPoker,
+			data:     calculations[result.Player.ID],
+			position: result.Position,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getPokerWinner returns the winning hand of a dealt game, or nil before the
+// deal. Players holding an equal hand share the win, as they share the pot.
+func (s *PuttPuttPokerBet) getPokerWinner(gameID string) (*models.Winner, error) {
+	rows, err := s.db.Query(`
+		SELECT h.player_id, h.hand_type, h.hand_description, h.best_hand_cards
+		FROM poker_hands h
+		JOIN players p ON p.id = h.player_id
+		WHERE h.game_id = ? AND h.position = 1
+		ORDER BY p.position
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var winner *models.Winner
+	for rows.Next() {
+		var playerID, handType, bestJSON string
+		var description sql.NullString
+		if err := rows.Scan(&playerID, &handType, &description, &bestJSON); err != nil {
+			return nil, err
+		}
+
+		if winner != nil {
+			winner.TiedWith = append(winner.TiedWith, playerID)
+			winner.Tiebreak = tiebreakShared
+			continue
+		}
+
+		winner = &models.Winner{
+			PlayerID: playerID,
+			Score:    handType,
+			Hand:     description.String,
+		}
+		if err := json.Unmarshal([]byte(bestJSON), &winner.Cards); err != nil {
+			return nil, err
+		}
+	}
+
+	return winner, rows.Err()
+}
+
+// recordPokerWinner adds the poker winner to a completed game's final results
+func (s *PuttPuttPokerBet) recordPokerWinner(gameID string) error {
+	winner, err := s.getPokerWinner(gameID)
+	if err != nil || winner == nil {
+		return err
+	}
+
+	var finalResultsJSON sql.NullString
+	if err := s.db.QueryRow("SELECT final_results FROM games WHERE id = ?", gameID).Scan(&finalResultsJSON); err != nil {
+		return err
+	}
+
+	game := &models.Game{}
+	if finalResultsJSON.Valid {
+		if err := game.UnmarshalFinalResults(finalResultsJSON.String); err != nil {
+			return err
+		}
+	}
+	if game.FinalResults == nil {
+		game.FinalResults = &models.FinalResults{}
+	}
+	game.FinalResults.PuttPuttPokerWinner = winner
+
+	data, err := game.MarshalFinalResults()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec("UPDATE games SET final_results = ? WHERE id = ?", data, gameID)
+	return err
+}
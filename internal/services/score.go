@@ -14,12 +14,21 @@ import (
 
 // ScoreService handles score-related business logic
 type ScoreService struct {
-	db *sql.DB
+	db       *sql.DB
+	sideBets *SideBetService
+	locks    *gameLocks
 }
 
-// NewScoreService creates a new score service
-func NewScoreService(db *sql.DB) *ScoreService {
-	return &ScoreService{db: db}
+// NewScoreService creates a new score service. The side bet service adds side
+// bet standings to the leaderboard, and may be nil where only scoring is needed.
+func NewScoreService(db *sql.DB, sideBets *SideBetService) *ScoreService {
+	return &ScoreService{db: db, sideBets: sideBets, locks: newGameLocks()}
+}
+
+// LockScores holds off leaderboards for a game while a score and its side bet
+// updates are saved, and returns the function that releases them
+func (s *ScoreService) LockScores(gameID string) func() {
+	return s.locks.lock(gameID)
 }
 
 // RecordScore records a score for a player on a specific hole
@@ -35,17 +44,26 @@ func (s *ScoreService) RecordScore(gameID, playerID string, req *models.ScoreReq
 	}
 
 	// Get hole par from course data
-	par, err := s.getHolePar(gameID, req.Hole)
+	par, err := s.getHolePar(gameID, playerID, req.Hole)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hole par: %w", err)
 	}
 
-	// Calculate handicap stroke (simplified logic for now)
-	handicapStroke := s.calculateHandicapStroke(gameID, playerID, req.Hole)
+	// Allocate the player's handicap strokes for the hole
+	handicapStrokes, err := s.getHandicapStrokes(gameID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get handicap strokes: %w", err)
+	}
+
+	// Cap the hole for adjusted gross scores
+	maxScore, err := s.getMaxScoreCap(gameID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max score rule: %w", err)
+	}
 
 	// Calculate scores
 	scoreToPar := req.Strokes - par
-	effectiveScore := models.CalculateEffectiveScore(req.Strokes, par, handicapStroke)
+	effectiveScore := models.CalculateEffectiveScore(req.Strokes, par, handicapStrokes[req.Hole])
 
 	// Generate score ID
 	scoreID, err := auth.GenerateScoreID()
@@ -55,16 +73,17 @@ func (s *ScoreService) RecordScore(gameID, playerID string, req *models.ScoreReq
 
 	// Create score
 	score := &models.Score{
-		ID:             scoreID,
-		PlayerID:       playerID,
-		GameID:         gameID,
-		Hole:           req.Hole,
-		Strokes:        req.Strokes,
-		Putts:          req.Putts,
-		Par:            par,
-		HandicapStroke: handicapStroke,
-		EffectiveScore: effectiveScore,
-		CreatedAt:      time.Now(),
+		ID:              scoreID,
+		PlayerID:        playerID,
+		GameID:          gameID,
+		Hole:            req.Hole,
+		Strokes:         req.Strokes,
+		AdjustedStrokes: maxScore.adjustedStrokes(req.Hole, req.Strokes),
+		Putts:           req.Putts,
+		Par:             par,
+		HandicapStrokes: handicapStrokes[req.Hole],
+		EffectiveScore:  effectiveScore,
+		CreatedAt:       time.Now(),
 	}
 
 	// Format score to par
@@ -73,9 +92,9 @@ func (s *ScoreService) RecordScore(gameID, playerID string, req *models.ScoreReq
 	// Insert into database
 	query := `
 		INSERT INTO scores (
-			id, player_id, game_id, hole, strokes, putts, par,
-			handicap_stroke, score_to_par, effective_score, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			id, player_id, game_id, hole, strokes, adjusted_strokes, putts, par,
+			handicap_strokes, score_to_par, effective_score, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err = s.db.Exec(
 		query,
@@ -84,9 +103,10 @@ func (s *ScoreService) RecordScore(gameID, playerID string, req *models.ScoreReq
 		score.GameID,
 		score.Hole,
 		score.Strokes,
+		score.AdjustedStrokes,
 		score.Putts,
 		score.Par,
-		score.HandicapStroke,
+		score.HandicapStrokes,
 		scoreToPar,
 		score.EffectiveScore,
 		score.CreatedAt,
@@ -142,18 +162,24 @@ func (s *ScoreService) UpdateScore(gameID, playerID string, hole int, req *model
 		)
 	}
 
+	maxScore, err := s.getMaxScoreCap(gameID, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get max score rule: %w", err)
+	}
+
 	// Recalculate derived values
 	scoreToPar := strokes - existingScore.Par
-	effectiveScore := models.CalculateEffectiveScore(strokes, existingScore.Par, existingScore.HandicapStroke)
+	effectiveScore := models.CalculateEffectiveScore(strokes, existingScore.Par, existingScore.HandicapStrokes)
+	adjusted := maxScore.adjustedStrokes(hole, strokes)
 
 	// Update database
 	now := time.Now()
 	query := `
 		UPDATE scores
-		SET strokes = ?, putts = ?, score_to_par = ?, effective_score = ?, updated_at = ?
+		SET strokes = ?, adjusted_strokes = ?, putts = ?, score_to_par = ?, effective_score = ?, updated_at = ?
 		WHERE id = ?
 	`
-	_, err = s.db.Exec(query, strokes, putts, scoreToPar, effectiveScore, now, existingScore.ID)
+	_, err = s.db.Exec(query, strokes, adjusted, putts, scoreToPar, effectiveScore, now, existingScore.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update score: %w", err)
 	}
@@ -171,13 +197,19 @@ func (s *ScoreService) GetGameScorecard(gameID string) (*models.GameScorecard, e
 	}
 
 	// Get course info
-	courseInfo, err := s.getCourseHoles(game.Course)
+	courseInfo, err := (&sideBetStore{db: s.db}).getCourseHoles(game.Course, models.GenderMale)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get scoring format for totals
+	scoring, err := s.getGameScoring(gameID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get players with scores
-	players, err := s.getPlayersWithScores(gameID)
+	players, err := s.getPlayersWithScores(gameID, scoring)
 	if err != nil {
 		return nil, err
 	}
@@ -191,18 +223,38 @@ func (s *ScoreService) GetGameScorecard(gameID string) (*models.GameScorecard, e
 
 // GetLeaderboard returns the current game leaderboard
 func (s *ScoreService) GetLeaderboard(gameID string) (*models.Leaderboard, error) {
+	// Read the overall standings and side bets from the same scores
+	defer s.locks.rlock(gameID)()
+
 	// Get overall leaderboard
 	overall, err := s.getOverallLeaderboard(gameID)
 	if err != nil {
 		return nil, err
 	}
 
+	scoring, err := s.getGameScoring(gameID)
+	if err != nil {
+		return nil, err
+	}
+
 	leaderboard := &models.Leaderboard{
-		Overall: overall,
+		ScoringFormat: scoring.Format,
+		Overall:       overall,
 	}
 
-	// TODO: Add side bet leaderboards
-	// This would be implemented when side bet services are complete
+	if scoring.Format == models.ScoringMatchPlay {
+		leaderboard.Match, err = s.calculateMatchPlay(gameID, scoring)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s.sideBets != nil {
+		leaderboard.SideBets, err = s.sideBets.Leaderboard(gameID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return leaderboard, nil
 }
@@ -255,7 +307,8 @@ func (s *ScoreService) validateUpdateScoreRequest(req *models.UpdateScoreRequest
 func (s *ScoreService) validateScoreBusinessRules(gameID, playerID string, req *models.ScoreRequest) error {
 	// Check game exists and is in progress
 	var status string
-	err := s.db.QueryRow("SELECT status FROM games WHERE id = ?", gameID).Scan(&status)
+	var teamFormat sql.NullString
+	err := s.db.QueryRow("SELECT status, team_format FROM games WHERE id = ?", gameID).Scan(&status, &teamFormat)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.ResourceNotFoundError("Game", gameID)
@@ -272,6 +325,11 @@ func (s *ScoreService) validateScoreBusinessRules(gameID, playerID string, req *
 		)
 	}
 
+	// Scramble teams play one ball, so there are no individual scores
+	if teamFormat.String == string(models.TeamScramble) {
+		return errors.New(errors.ErrInvalidGameState, "Scramble scores are recorded per team")
+	}
+
 	// Check player exists in game
 	var count int
 	err = s.db.QueryRow("SELECT COUNT(*) FROM players WHERE id = ? AND game_id = ?", playerID, gameID).Scan(&count)
@@ -296,7 +354,25 @@ func (s *ScoreService) validateScoreBusinessRules(gameID, playerID string, req *
 	return nil
 }
 
-func (s *ScoreService) getHolePar(gameID string, hole int) (int, error) {
+// getHolePar returns a player's par for a hole, from the card for their
+// gender
+func (s *ScoreService) getHolePar(gameID, playerID string, hole int) (int, error) {
+	course, err := (&sideBetStore{db: s.db}).getPlayerCourse(gameID, playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	par := course.par(hole)
+	if par == 0 {
+		return 0, errors.ValidationError("hole", fmt.Sprintf("%d", hole), "invalid hole number for course")
+	}
+
+	return par, nil
+}
+
+// getCourseHolePar returns the par for a hole on the course's men's card,
+// which scramble teams play to
+func (s *ScoreService) getCourseHolePar(gameID string, hole int) (int, error) {
 	// Get course name first
 	var course string
 	err := s.db.QueryRow("SELECT course FROM games WHERE id = ?", gameID).Scan(&course)
@@ -306,7 +382,10 @@ func (s *ScoreService) getHolePar(gameID string, hole int) (int, error) {
 
 	// Get hole par
 	var par int
-	err = s.db.QueryRow("SELECT par FROM course_data WHERE course_name = ? AND hole = ?", course, hole).Scan(&par)
+	err = s.db.QueryRow(
+		"SELECT par FROM course_data WHERE course_name = ? AND gender = ? AND hole = ?",
+		course, models.GenderMale, hole,
+	).Scan(&par)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, errors.ValidationError("hole", fmt.Sprintf("%d", hole), "invalid hole number for course")
@@ -317,16 +396,81 @@ func (s *ScoreService) getHolePar(gameID string, hole int) (int, error) {
 	return par, nil
 }
 
-func (s *ScoreService) calculateHandicapStroke(gameID, playerID string, hole int) bool {
-	// Simplified handicap calculation - would need more complex logic in production
-	// This is a placeholder that returns false for now
-	return false
+// getHandicapStrokes returns the handicap strokes a player receives on each
+// hole, allocating their playing handicap by stroke index. Plus handicaps
+// give strokes back on the easiest holes, and every hole gets none when
+// handicaps are disabled.
+func (s *ScoreService) getHandicapStrokes(gameID, playerID string) (map[int]int, error) {
+	var handicap float64
+	err := s.db.QueryRow("SELECT handicap FROM players WHERE id = ? AND game_id = ?", playerID, gameID).Scan(&handicap)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Player", playerID)
+		}
+		return nil, err
+	}
+
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	strokes := make(map[int]int, roundHoles)
+	if !handicaps.enabled {
+		return strokes, nil
+	}
+
+	playing := handicaps.playingHandicap(playerID, handicap)
+	for _, hole := range handicaps.courses[playerID].holes {
+		strokes[hole.Hole] = allocatedStrokes(playing, hole.HandicapRanking)
+	}
+
+	return strokes, nil
+}
+
+// recalculateHandicapStrokes reallocates a player's handicap strokes and
+// rescores every hole they have recorded, used when their handicap changes
+func (s *ScoreService) recalculateHandicapStrokes(gameID, playerID string) error {
+	strokes, err := s.getHandicapStrokes(gameID, playerID)
+	if err != nil {
+		return err
+	}
+
+	maxScore, err := s.getMaxScoreCap(gameID, playerID)
+	if err != nil {
+		return err
+	}
+
+	scores, err := s.getPlayerScores(playerID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, score := range scores {
+		effectiveScore := models.CalculateEffectiveScore(score.Strokes, score.Par, strokes[score.Hole])
+		adjusted := maxScore.adjustedStrokes(score.Hole, score.Strokes)
+		_, err := tx.Exec(
+			"UPDATE scores SET handicap_strokes = ?, adjusted_strokes = ?, effective_score = ? WHERE id = ?",
+			strokes[score.Hole], adjusted, effectiveScore, score.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to rescore hole %d: %w", score.Hole, err)
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (s *ScoreService) getScore(gameID, playerID string, hole int) (*models.Score, error) {
 	query := `
-		SELECT id, player_id, game_id, hole, strokes, putts, par,
-		       handicap_stroke, score_to_par, effective_score,
+		SELECT id, player_id, game_id, hole, strokes, adjusted_strokes, putts, par,
+		       handicap_strokes, score_to_par, effective_score,
 		       created_at, updated_at
 		FROM scores
 		WHERE game_id = ? AND player_id = ? AND hole = ?
@@ -341,9 +485,10 @@ func (s *ScoreService) getScore(gameID, playerID string, hole int) (*models.Scor
 		&score.GameID,
 		&score.Hole,
 		&score.Strokes,
+		&score.AdjustedStrokes,
 		&score.Putts,
 		&score.Par,
-		&score.HandicapStroke,
+		&score.HandicapStrokes,
 		&score.ScoreToPar,
 		&score.EffectiveScore,
 		&score.CreatedAt,
@@ -379,55 +524,10 @@ func (s *ScoreService) getGameSummary(gameID string) (*models.GameSummary, error
 	return &game, nil
 }
 
-func (s *ScoreService) getCourseHoles(courseName string) ([]models.HoleInfo, error) {
-	query := `
-		SELECT hole, par, handicap_ranking, yardage, description
-		FROM course_data
-		WHERE course_name = ?
-		ORDER BY hole
-	`
-	rows, err := s.db.Query(query, courseName)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var holes []models.HoleInfo
-	for rows.Next() {
-		var hole models.HoleInfo
-		var yardage sql.NullInt64
-		var description sql.NullString
-
-		err := rows.Scan(
-			&hole.Hole,
-			&hole.Par,
-			&hole.HandicapRanking,
-			&yardage,
-			&description,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		if yardage.Valid {
-			y := int(yardage.Int64)
-			hole.Yardage = &y
-		}
-
-		if description.Valid {
-			hole.Description = description.String
-		}
-
-		holes = append(holes, hole)
-	}
-
-	return holes, nil
-}
-
-func (s *ScoreService) getPlayersWithScores(gameID string) ([]models.ScorecardPlayer, error) {
+func (s *ScoreService) getPlayersWithScores(gameID string, scoring *gameScoring) ([]models.ScorecardPlayer, error) {
 	// Get players
 	playersQuery := `
-		SELECT id, name, position
+		SELECT id, name, handicap, position
 		FROM players
 		WHERE game_id = ?
 		ORDER BY position
@@ -438,23 +538,32 @@ func (s *ScoreService) getPlayersWithScores(gameID string) ([]models.ScorecardPl
 	}
 	defer rows.Close()
 
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
 	var players []models.ScorecardPlayer
 	for rows.Next() {
 		var player models.ScorecardPlayer
-		err := rows.Scan(&player.ID, &player.Name, &player.Position)
+		var handicap float64
+		err := rows.Scan(&player.ID, &player.Name, &handicap, &player.Position)
 		if err != nil {
 			return nil, err
 		}
 
+		// Show the card the player plays to
+		course := handicaps.courses[player.ID]
+		player.Tee = course.tee
+		player.Holes = course.holes
+
 		// Get scores for this player
 		scores, err := s.getPlayerScores(player.ID)
 		if err != nil {
 			return nil, err
 		}
 		player.Scores = scores
-
-		// TODO: Calculate totals
-		// player.Totals = calculatePlayerTotals(scores)
+		player.Totals = calculatePlayerTotals(scores, scoring, float64(handicaps.playingHandicap(player.ID, handicap)))
 
 		players = append(players, player)
 	}
@@ -464,8 +573,8 @@ func (s *ScoreService) getPlayersWithScores(gameID string) ([]models.ScorecardPl
 
 func (s *ScoreService) getPlayerScores(playerID string) ([]models.Score, error) {
 	query := `
-		SELECT id, player_id, game_id, hole, strokes, putts, par,
-		       handicap_stroke, score_to_par, effective_score,
+		SELECT id, player_id, game_id, hole, strokes, adjusted_strokes, putts, par,
+		       handicap_strokes, score_to_par, effective_score,
 		       created_at, updated_at
 		FROM scores
 		WHERE player_id = ?
@@ -488,9 +597,10 @@ func (s *ScoreService) getPlayerScores(playerID string) ([]models.Score, error)
 			&score.GameID,
 			&score.Hole,
 			&score.Strokes,
+			&score.AdjustedStrokes,
 			&score.Putts,
 			&score.Par,
-			&score.HandicapStroke,
+			&score.HandicapStrokes,
 			&score.ScoreToPar,
 			&score.EffectiveScore,
 			&score.CreatedAt,
@@ -512,6 +622,22 @@ func (s *ScoreService) getPlayerScores(playerID string) ([]models.Score, error)
 }
 
 func (s *ScoreService) getOverallLeaderboard(gameID string) ([]models.LeaderboardEntry, error) {
+	scoring, err := s.getGameScoring(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case scoring.Format.IsStableford():
+		return s.getStablefordLeaderboard(gameID, scoring)
+	case scoring.Format == models.ScoringMatchPlay:
+		return s.getMatchPlayLeaderboard(gameID, scoring)
+	default:
+		return s.getStrokePlayLeaderboard(gameID)
+	}
+}
+
+func (s *ScoreService) getStrokePlayLeaderboard(gameID string) ([]models.LeaderboardEntry, error) {
 	query := `
 		SELECT p.id, p.name, p.handicap,
 		       COUNT(s.id) as holes_completed,
@@ -530,6 +656,11 @@ func (s *ScoreService) getOverallLeaderboard(gameID string) ([]models.Leaderboar
 	}
 	defer rows.Close()
 
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
 	var entries []models.LeaderboardEntry
 	position := 1
 
@@ -554,6 +685,7 @@ func (s *ScoreService) getOverallLeaderboard(gameID string) ([]models.Leaderboar
 			h := handicap.Float64
 			entry.Player.Handicap = &h
 		}
+		handicaps.applyToSummary(&entry.Player)
 
 		entry.Position = position
 		entry.Score = models.FormatScoreToPar(totalScore, 0) // Format relative to par
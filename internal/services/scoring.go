@@ -0,0 +1,373 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// Limits on a custom Stableford point value
+const (
+	minStablefordPoints = -10
+	maxStablefordPoints = 10
+)
+
+// validateScoringFormat resolves a requested scoring format and point table.
+// Stroke play is the default, and the point table is only configurable for
+// modified Stableford.
+func validateScoringFormat(format models.ScoringFormat, points *models.StablefordPointTable) (models.ScoringFormat, *models.StablefordPointTable, error) {
+	if format == "" {
+		format = models.ScoringStrokePlay
+	}
+
+	switch format {
+	case models.ScoringStrokePlay, models.ScoringStableford, models.ScoringMatchPlay:
+		if points != nil {
+			return "", nil, errors.ValidationError("stableford_points", string(format), "only configurable for modified-stableford")
+		}
+		return format, models.DefaultStablefordPoints(format), nil
+	case models.ScoringModifiedStableford:
+		if points == nil {
+			return format, models.DefaultStablefordPoints(format), nil
+		}
+		if err := validateStablefordPoints(points); err != nil {
+			return "", nil, err
+		}
+		return format, points, nil
+	default:
+		return "", nil, errors.ValidationErrorWithAllowedValues("scoring_format", string(format), []interface{}{
+			models.ScoringStrokePlay,
+			models.ScoringStableford,
+			models.ScoringModifiedStableford,
+			models.ScoringMatchPlay,
+		})
+	}
+}
+
+// validateStablefordPoints checks a custom point table. Better results must
+// never earn fewer points than worse ones.
+func validateStablefordPoints(points *models.StablefordPointTable) error {
+	values := []struct {
+		field string
+		value int
+	}{
+		{"albatross", points.Albatross},
+		{"eagle", points.Eagle},
+		{"birdie", points.Birdie},
+		{"par", points.Par},
+		{"bogey", points.Bogey},
+		{"double_bogey", points.DoubleBogey},
+	}
+
+	for i, v := range values {
+		field := "stableford_points." + v.field
+		if v.value < minStablefordPoints || v.value > maxStablefordPoints {
+			return errors.ValidationError(field, fmt.Sprintf("%d", v.value), fmt.Sprintf("must be between %d and %d", minStablefordPoints, maxStablefordPoints))
+		}
+		if i > 0 && v.value > values[i-1].value {
+			return errors.ValidationError(field, fmt.Sprintf("%d", v.value), fmt.Sprintf("must not be more than %s", values[i-1].field))
+		}
+	}
+
+	return nil
+}
+
+// gameScoring is a game's scoring format with what is needed to score holes
+type gameScoring struct {
+	Format          models.ScoringFormat
+	Points          *models.StablefordPointTable
+	HandicapEnabled bool
+	Tiebreak        models.TiebreakPolicy
+	Courses         map[string]*playerCourse // Keyed by player ID
+}
+
+// ranking returns a player's stroke index for a hole
+func (g *gameScoring) ranking(playerID string, hole int) int {
+	return g.Courses[playerID].ranking(hole)
+}
+
+// holePoints returns a player's Stableford points for a hole. Net points take
+// off the strokes their handicap allocates to the hole.
+func (g *gameScoring) holePoints(playerID string, hole, strokes, par int, handicap float64) int {
+	if g.HandicapEnabled {
+		strokes -= allocatedStrokes(roundedHandicap(&handicap), g.ranking(playerID, hole))
+	}
+	return g.Points.Points(strokes, par)
+}
+
+// getGameScoring loads the scoring format of a game
+func (s *ScoreService) getGameScoring(gameID string) (*gameScoring, error) {
+	var scoring gameScoring
+	var pointsJSON sql.NullString
+
+	err := s.db.QueryRow(`
+		SELECT scoring_format, stableford_points, handicap_enabled, tiebreak
+		FROM games WHERE id = ?
+	`, gameID).Scan(&scoring.Format, &pointsJSON, &scoring.HandicapEnabled, &scoring.Tiebreak)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Game", gameID)
+		}
+		return nil, err
+	}
+
+	if scoring.Format.IsStableford() {
+		game := models.Game{}
+		if err := game.UnmarshalStablefordPoints(pointsJSON.String); err != nil {
+			return nil, err
+		}
+		scoring.Points = game.StablefordPoints
+		if scoring.Points == nil {
+			scoring.Points = models.DefaultStablefordPoints(scoring.Format)
+		}
+	}
+
+	courses, err := (&sideBetStore{db: s.db}).getPlayerCourses(gameID)
+	if err != nil {
+		return nil, err
+	}
+	scoring.Courses = courses
+
+	return &scoring, nil
+}
+
+// roundHole is a recorded hole used to rank players
+type roundHole struct {
+	strokes         int
+	par             int
+	putts           int
+	handicapStrokes int
+}
+
+// playerRound is a player's recorded holes for ranking
+type playerRound struct {
+	player         models.PlayerSummary
+	handicap       float64 // Playing handicap
+	holes          map[int]roundHole
+	holesCompleted int
+	totalPutts     int
+}
+
+// getPlayerRounds loads every player's recorded holes in position order
+func (s *ScoreService) getPlayerRounds(gameID string) ([]*playerRound, error) {
+	rows, err := s.db.Query(`
+		SELECT p.id, p.name, p.handicap, s.hole, s.strokes, s.par, s.putts, s.handicap_strokes
+		FROM players p
+		LEFT JOIN scores s ON p.id = s.player_id
+		WHERE p.game_id = ?
+		ORDER BY p.position, s.hole
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rounds []*playerRound
+	index := make(map[string]*playerRound)
+
+	for rows.Next() {
+		var playerID, name string
+		var handicap float64
+		var hole, strokes, par, putts sql.NullInt64
+		var handicapStrokes sql.NullInt64
+
+		if err := rows.Scan(&playerID, &name, &handicap, &hole, &strokes, &par, &putts, &handicapStrokes); err != nil {
+			return nil, err
+		}
+
+		round, ok := index[playerID]
+		if !ok {
+			h := handicap
+			round = &playerRound{
+				player:   models.PlayerSummary{ID: playerID, Name: name, Handicap: &h},
+				handicap: float64(handicaps.playingHandicap(playerID, handicap)),
+				holes:    make(map[int]roundHole, roundHoles),
+			}
+			handicaps.applyToSummary(&round.player)
+			index[playerID] = round
+			rounds = append(rounds, round)
+		}
+
+		if !hole.Valid {
+			continue
+		}
+
+		round.holes[int(hole.Int64)] = roundHole{
+			strokes:         int(strokes.Int64),
+			par:             int(par.Int64),
+			putts:           int(putts.Int64),
+			handicapStrokes: int(handicapStrokes.Int64),
+		}
+		round.holesCompleted++
+		round.totalPutts += int(putts.Int64)
+	}
+
+	return rounds, rows.Err()
+}
+
+// getStablefordLeaderboard ranks players by Stableford points, most first
+func (s *ScoreService) getStablefordLeaderboard(gameID string, scoring *gameScoring) ([]models.LeaderboardEntry, error) {
+	rounds, err := s.getPlayerRounds(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.LeaderboardEntry, 0, len(rounds))
+	for _, round := range rounds {
+		points := 0
+		for hole, score := range round.holes {
+			points += scoring.holePoints(round.player.ID, hole, score.strokes, score.par, round.handicap)
+		}
+		entries = append(entries, models.LeaderboardEntry{
+			Player:         round.player,
+			Score:          formatStablefordPoints(points),
+			Points:         &points,
+			HolesCompleted: round.holesCompleted,
+			TotalPutts:     round.totalPutts,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if *entries[i].Points != *entries[j].Points {
+			return *entries[i].Points > *entries[j].Points
+		}
+		return entries[i].HolesCompleted > entries[j].HolesCompleted
+	})
+	for i := range entries {
+		entries[i].Position = i + 1
+	}
+
+	return entries, nil
+}
+
+// finalizeOverall records the overall winner for the game's scoring format.
+// Match play records the final match, and other formats take the leader.
+// Stroke play with handicaps also records the net winner. Ties for first are
+// broken by the game's tiebreak policy.
+func (s *ScoreService) finalizeOverall(gameID string, results *models.FinalResults) error {
+	scoring, err := s.getGameScoring(gameID)
+	if err != nil {
+		return err
+	}
+
+	if scoring.Format == models.ScoringMatchPlay {
+		match, err := s.calculateMatchPlay(gameID, scoring)
+		if err != nil || match == nil {
+			return err
+		}
+		results.Match = match
+		results.OverallWinner = matchPlayWinner(match)
+		return nil
+	}
+
+	rounds, err := s.getPlayerRounds(gameID)
+	if err != nil {
+		return err
+	}
+
+	if scoring.Format.IsStableford() {
+		points := make([]roundResult, 0, len(rounds))
+		for _, round := range rounds {
+			result := newRoundResult(round)
+			for hole, score := range round.holes {
+				holePoints := float64(scoring.holePoints(round.player.ID, hole, score.strokes, score.par, round.handicap))
+				result.holes[hole] = holePoints
+				result.total += holePoints
+			}
+			points = append(points, result)
+		}
+
+		leaders := firstPlace(points, true)
+		if len(leaders) > 0 {
+			score := formatStablefordPoints(int(leaders[0].total))
+			results.OverallWinner = decideWinner(models.PlayoffOverall, score, leaders, scoring.Tiebreak, true, results)
+		}
+		return nil
+	}
+
+	gross := make([]roundResult, 0, len(rounds))
+	net := make([]roundResult, 0, len(rounds))
+	for _, round := range rounds {
+		grossResult := newRoundResult(round)
+		netResult := newRoundResult(round)
+		netResult.handicap = float64(roundedHandicap(&round.handicap))
+
+		for hole, score := range round.holes {
+			toPar := float64(score.strokes - score.par)
+			grossResult.holes[hole] = toPar
+			grossResult.total += toPar
+			netResult.holes[hole] = toPar
+			netResult.total += toPar - float64(allocatedStrokes(int(netResult.handicap), scoring.ranking(round.player.ID, hole)))
+		}
+		gross = append(gross, grossResult)
+		net = append(net, netResult)
+	}
+
+	if leaders := firstPlace(gross, false); len(leaders) > 0 {
+		score := models.FormatScoreToPar(int(leaders[0].total), 0)
+		results.OverallWinner = decideWinner(models.PlayoffOverall, score, leaders, scoring.Tiebreak, false, results)
+	}
+	if scoring.HandicapEnabled {
+		if leaders := firstPlace(net, false); len(leaders) > 0 {
+			score := models.FormatScoreToPar(int(leaders[0].total), 0)
+			results.NetWinner = decideWinner(models.PlayoffNet, score, leaders, scoring.Tiebreak, false, results)
+		}
+	}
+
+	return nil
+}
+
+// newRoundResult starts a player's result for ranking the round
+func newRoundResult(round *playerRound) roundResult {
+	return roundResult{
+		playerID:       round.player.ID,
+		holesCompleted: round.holesCompleted,
+		holes:          make(map[int]float64, len(round.holes)),
+	}
+}
+
+// calculatePlayerTotals sums a player's scorecard. Stableford formats also
+// record the points earned on each hole.
+func calculatePlayerTotals(scores []models.Score, scoring *gameScoring, handicap float64) *models.PlayerStats {
+	totals := &models.PlayerStats{}
+	strokes, par, points := 0, 0, 0
+
+	for i := range scores {
+		score := &scores[i]
+		totals.HolesCompleted++
+		totals.TotalPutts += score.Putts
+		totals.AdjustedStrokes += score.AdjustedStrokes
+		strokes += score.Strokes
+		par += score.Par
+
+		if scoring.Format.IsStableford() {
+			holePoints := scoring.holePoints(score.PlayerID, score.Hole, score.Strokes, score.Par, handicap)
+			score.Points = &holePoints
+			points += holePoints
+		}
+	}
+
+	totals.TotalStrokes = strokes
+	totals.CurrentScore = models.FormatScoreToPar(strokes, par)
+	if scoring.Format.IsStableford() {
+		totals.Points = &points
+	}
+
+	return totals
+}
+
+// formatStablefordPoints formats a points total for display
+func formatStablefordPoints(points int) string {
+	if points == 1 || points == -1 {
+		return fmt.Sprintf("%d pt", points)
+	}
+	return fmt.Sprintf("%d pts", points)
+}
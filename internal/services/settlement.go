@@ -0,0 +1,584 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/auth"
+	"golf-gamez/pkg/errors"
+)
+
+// SettlementService works out who owes whom once a game is completed. Each
+// side bet played for money reports its net amounts through SideBetSettler,
+// and the totals are settled with as few transfers as possible.
+type SettlementService struct {
+	sideBetStore
+	sideBets *SideBetRegistry
+}
+
+// NewSettlementService creates a new settlement service
+func NewSettlementService(db *sql.DB, sideBets *SideBetRegistry) *SettlementService {
+	return &SettlementService{
+		sideBetStore: sideBetStore{db: db},
+		sideBets:     sideBets,
+	}
+}
+
+// plannedTransfer is a payment in cents between two players
+type plannedTransfer struct {
+	id       string // Set once the transfer is stored
+	from, to string
+	cents    int64
+}
+
+// GetSettlement returns a completed game's settlement. The amounts and
+// transfers are stored when the game is completed and only change when
+// something moves them, such as a poker pot dealt afterwards or a transfer
+// marked paid, so reading the settlement never writes.
+func (s *SettlementService) GetSettlement(gameID string) (*models.Settlement, error) {
+	game, err := s.getGameInfo(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status != models.GameStatusCompleted {
+		return nil, errors.BusinessLogicError(
+			errors.ErrGameNotCompleted,
+			"Settlement is available once the game is completed",
+			string(game.Status),
+			string(models.GameStatusCompleted),
+		)
+	}
+
+	players, err := s.getPlayerSummaries(gameID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.PlayerSummary, len(players))
+	for _, player := range players {
+		byID[player.ID] = player
+	}
+
+	settlement := &models.Settlement{
+		GameID:    gameID,
+		Bets:      []models.SettlementBet{},
+		Balances:  make([]models.SettlementBalance, 0, len(players)),
+		Transfers: []models.SettlementTransfer{},
+	}
+
+	// Points-only games keep standings but have nothing to settle
+	stakes, err := s.getStakes(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if stakes != nil && stakes.PointsOnly {
+		settlement.PointsOnly = true
+		settlement.Settled = true
+		return settlement, nil
+	}
+
+	stored, err := s.getSettlementBets(gameID, byID)
+	if err != nil {
+		return nil, err
+	}
+
+	net := make(map[string]int64, len(players))
+	pending := false
+	for _, sideBetType := range game.SideBets {
+		entry, ok := stored[sideBetType]
+		if !ok {
+			continue
+		}
+		if entry.Pending {
+			pending = true
+		}
+		for _, amount := range entry.Amounts {
+			net[amount.Player.ID] += toCents(amount.Amount)
+		}
+		settlement.Bets = append(settlement.Bets, *entry)
+	}
+
+	transfers, err := s.getSettlementTransfers(gameID, byID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Paid transfers have already moved money
+	outstanding := make(map[string]int64, len(players))
+	for id, cents := range net {
+		outstanding[id] = cents
+	}
+	unpaid := 0
+	for _, transfer := range transfers {
+		if !transfer.Paid {
+			unpaid++
+		} else {
+			outstanding[transfer.From.ID] += toCents(transfer.Amount)
+			outstanding[transfer.To.ID] -= toCents(transfer.Amount)
+		}
+		settlement.Transfers = append(settlement.Transfers, transfer)
+	}
+
+	for _, player := range players {
+		settlement.Balances = append(settlement.Balances, models.SettlementBalance{
+			Player:      player,
+			Net:         fromCents(net[player.ID]),
+			Outstanding: fromCents(outstanding[player.ID]),
+		})
+	}
+	settlement.Settled = !pending && unpaid == 0
+
+	return settlement, nil
+}
+
+// settleBets gathers the net amounts of every side bet in a game that is
+// played for money. It only reads, so a game's settlement can be worked out
+// before the game is marked completed.
+func (s *SettlementService) settleBets(gameID string, sideBets []models.SideBetType) ([]models.SettlementBet, error) {
+	var bets []models.SettlementBet
+	for _, sideBetType := range sideBets {
+		bet, ok := s.sideBets.Get(sideBetType)
+		if !ok {
+			continue
+		}
+		settler, ok := bet.(SideBetSettler)
+		if !ok {
+			continue
+		}
+
+		entry, err := settleBet(gameID, sideBetType, settler)
+		if err != nil {
+			return nil, err
+		}
+		bets = append(bets, entry)
+	}
+	return bets, nil
+}
+
+// settleBet asks one side bet for its net amounts. A bet that can't be
+// settled yet is marked pending with no amounts.
+func settleBet(gameID string, betType models.SideBetType, settler SideBetSettler) (models.SettlementBet, error) {
+	amounts, err := settler.Settle(gameID)
+	if err != nil {
+		return models.SettlementBet{}, fmt.Errorf("failed to settle %s: %w", betType, err)
+	}
+
+	entry := models.SettlementBet{BetType: betType, Pending: amounts == nil, Amounts: amounts}
+	if entry.Pending {
+		entry.Amounts = []models.SettlementAmount{}
+	}
+	return entry, nil
+}
+
+// UpdateTransfer marks a transfer paid or unpaid and returns the updated
+// settlement. Marking a transfer unpaid puts its amount back into the plan.
+func (s *SettlementService) UpdateTransfer(gameID, transferID string, req *models.UpdateTransferRequest) (*models.Settlement, error) {
+	if req.Paid == nil {
+		return nil, errors.NewWithDetails(errors.ErrMissingRequiredField, "Field 'paid' is required", map[string]interface{}{
+			"field": "paid",
+		})
+	}
+
+	game, err := s.getGameInfo(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status != models.GameStatusCompleted {
+		return nil, errors.BusinessLogicError(
+			errors.ErrGameNotCompleted,
+			"Settlement is available once the game is completed",
+			string(game.Status),
+			string(models.GameStatusCompleted),
+		)
+	}
+
+	var paidAt *time.Time
+	if *req.Paid {
+		now := time.Now()
+		paidAt = &now
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE settlement_transfers
+		SET paid = ?, paid_at = ?
+		WHERE id = ? AND game_id = ?
+	`, *req.Paid, paidAt, transferID, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, errors.ResourceNotFoundError("Transfer", transferID)
+	}
+
+	if err := s.planSettlementTransfers(tx, gameID); err != nil {
+		return nil, fmt.Errorf("failed to save settlement transfers: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update transfer: %w", err)
+	}
+
+	return s.GetSettlement(gameID)
+}
+
+// saveSettlement stores each bet's net amounts, replacing whatever was
+// recorded for those bets before, and replans the game's transfers
+func (s *sideBetStore) saveSettlement(tx *sql.Tx, gameID string, bets []models.SettlementBet) error {
+	now := time.Now()
+	for _, bet := range bets {
+		if _, err := tx.Exec("DELETE FROM settlement_amounts WHERE game_id = ? AND bet_type = ?", gameID, bet.BetType); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO settlement_bets (game_id, bet_type, pending, updated_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (game_id, bet_type) DO UPDATE SET pending = excluded.pending, updated_at = excluded.updated_at
+		`, gameID, bet.BetType, bet.Pending, now)
+		if err != nil {
+			return err
+		}
+
+		for _, amount := range bet.Amounts {
+			_, err := tx.Exec(`
+				INSERT INTO settlement_amounts (game_id, player_id, bet_type, amount, updated_at)
+				VALUES (?, ?, ?, ?, ?)
+			`, gameID, amount.Player.ID, bet.BetType, amount.Amount, now)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.planSettlementTransfers(tx, gameID)
+}
+
+// resettleBet works out one bet's amounts again and updates the stored
+// settlement, for a bet that settles after the game is completed
+func (s *sideBetStore) resettleBet(gameID string, betType models.SideBetType, settler SideBetSettler) error {
+	entry, err := settleBet(gameID, betType, settler)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := s.saveSettlement(tx, gameID, []models.SettlementBet{entry}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// planSettlementTransfers plans the transfers that settle the stored
+// amounts. Paid transfers are kept and the plan covers whatever is still
+// outstanding; unpaid transfers still in the plan keep their IDs, and the
+// rest are replaced.
+func (s *sideBetStore) planSettlementTransfers(tx *sql.Tx, gameID string) error {
+	playerRows, err := tx.Query("SELECT id FROM players WHERE game_id = ? ORDER BY position", gameID)
+	if err != nil {
+		return err
+	}
+	var playerIDs []string
+	for playerRows.Next() {
+		var id string
+		if err := playerRows.Scan(&id); err != nil {
+			playerRows.Close()
+			return err
+		}
+		playerIDs = append(playerIDs, id)
+	}
+	playerRows.Close()
+	if err := playerRows.Err(); err != nil {
+		return err
+	}
+
+	outstanding := make(map[string]int64, len(playerIDs))
+	amountRows, err := tx.Query("SELECT player_id, amount FROM settlement_amounts WHERE game_id = ?", gameID)
+	if err != nil {
+		return err
+	}
+	for amountRows.Next() {
+		var playerID string
+		var amount float64
+		if err := amountRows.Scan(&playerID, &amount); err != nil {
+			amountRows.Close()
+			return err
+		}
+		outstanding[playerID] += toCents(amount)
+	}
+	amountRows.Close()
+	if err := amountRows.Err(); err != nil {
+		return err
+	}
+
+	transferRows, err := tx.Query(`
+		SELECT id, from_player_id, to_player_id, amount, paid
+		FROM settlement_transfers
+		WHERE game_id = ?
+		ORDER BY created_at, rowid
+	`, gameID)
+	if err != nil {
+		return err
+	}
+	var unpaid []plannedTransfer
+	for transferRows.Next() {
+		var transfer plannedTransfer
+		var amount float64
+		var paid bool
+		if err := transferRows.Scan(&transfer.id, &transfer.from, &transfer.to, &amount, &paid); err != nil {
+			transferRows.Close()
+			return err
+		}
+		transfer.cents = toCents(amount)
+		if !paid {
+			unpaid = append(unpaid, transfer)
+			continue
+		}
+		outstanding[transfer.from] += transfer.cents
+		outstanding[transfer.to] -= transfer.cents
+	}
+	transferRows.Close()
+	if err := transferRows.Err(); err != nil {
+		return err
+	}
+
+	for _, planned := range planTransfers(playerIDs, outstanding) {
+		kept := -1
+		for i, existing := range unpaid {
+			if existing.from == planned.from && existing.to == planned.to && existing.cents == planned.cents {
+				kept = i
+				break
+			}
+		}
+		if kept >= 0 {
+			unpaid = append(unpaid[:kept], unpaid[kept+1:]...)
+			continue
+		}
+
+		transferID, err := auth.GenerateTransferID()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`
+			INSERT INTO settlement_transfers (id, game_id, from_player_id, to_player_id, amount, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, transferID, gameID, planned.from, planned.to, fromCents(planned.cents), time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	// Whatever is left is no longer part of the plan
+	for _, stale := range unpaid {
+		if _, err := tx.Exec("DELETE FROM settlement_transfers WHERE id = ?", stale.id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSettlementBets loads the stored amounts of each settled bet
+func (s *SettlementService) getSettlementBets(gameID string, byID map[string]models.PlayerSummary) (map[models.SideBetType]*models.SettlementBet, error) {
+	rows, err := s.db.Query("SELECT bet_type, pending FROM settlement_bets WHERE game_id = ?", gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bets := make(map[models.SideBetType]*models.SettlementBet)
+	for rows.Next() {
+		bet := &models.SettlementBet{Amounts: []models.SettlementAmount{}}
+		if err := rows.Scan(&bet.BetType, &bet.Pending); err != nil {
+			return nil, err
+		}
+		bets[bet.BetType] = bet
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	amountRows, err := s.db.Query(`
+		SELECT a.bet_type, a.player_id, a.amount
+		FROM settlement_amounts a
+		JOIN players p ON p.id = a.player_id
+		WHERE a.game_id = ?
+		ORDER BY p.position
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer amountRows.Close()
+
+	for amountRows.Next() {
+		var betType models.SideBetType
+		var playerID string
+		var amount float64
+		if err := amountRows.Scan(&betType, &playerID, &amount); err != nil {
+			return nil, err
+		}
+		if bet, ok := bets[betType]; ok {
+			bet.Amounts = append(bet.Amounts, models.SettlementAmount{Player: byID[playerID], Amount: amount})
+		}
+	}
+
+	return bets, amountRows.Err()
+}
+
+// getSettlementTransfers loads a game's stored transfers, paid ones first
+func (s *SettlementService) getSettlementTransfers(gameID string, byID map[string]models.PlayerSummary) ([]models.SettlementTransfer, error) {
+	rows, err := s.db.Query(`
+		SELECT id, from_player_id, to_player_id, amount, paid, paid_at
+		FROM settlement_transfers
+		WHERE game_id = ?
+		ORDER BY paid DESC, paid_at, created_at, rowid
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transfers []models.SettlementTransfer
+	for rows.Next() {
+		var transfer models.SettlementTransfer
+		var fromID, toID string
+		var paidAt sql.NullTime
+
+		if err := rows.Scan(&transfer.ID, &fromID, &toID, &transfer.Amount, &transfer.Paid, &paidAt); err != nil {
+			return nil, err
+		}
+
+		transfer.From = byID[fromID]
+		transfer.To = byID[toID]
+		if paidAt.Valid {
+			transfer.PaidAt = &paidAt.Time
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// planTransfers returns the fewest transfers that bring every balance to
+// zero. Balances are in cents, positive for players who are owed. Players
+// are split into the most groups whose balances cancel out, since a group
+// of n players needs n-1 transfers; each group then settles by pairing its
+// largest debtor with its largest creditor.
+func planTransfers(playerIDs []string, balances map[string]int64) []plannedTransfer {
+	var ids []string
+	for _, id := range playerIDs {
+		if balances[id] != 0 {
+			ids = append(ids, id)
+		}
+	}
+	n := len(ids)
+	if n == 0 {
+		return nil
+	}
+
+	full := 1<<n - 1
+	sums := make([]int64, full+1)
+	groups := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				sums[mask] = sums[mask^(1<<i)] + balances[ids[i]]
+				break
+			}
+		}
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 && groups[mask^(1<<i)] > groups[mask] {
+				groups[mask] = groups[mask^(1<<i)]
+			}
+		}
+		if sums[mask] == 0 {
+			groups[mask]++
+		}
+	}
+
+	// Peel players off in an order that keeps the most zero-sum groups,
+	// closing a group each time the remaining players cancel out
+	var plan []plannedTransfer
+	var group []string
+	for mask := full; mask != 0; {
+		for i := n - 1; i >= 0; i-- {
+			bit := 1 << i
+			if mask&bit == 0 {
+				continue
+			}
+			closes := 0
+			if sums[mask] == 0 {
+				closes = 1
+			}
+			if groups[mask^bit]+closes == groups[mask] {
+				if closes == 1 && len(group) > 0 {
+					plan = append(plan, settleGroup(group, balances)...)
+					group = nil
+				}
+				group = append(group, ids[i])
+				mask ^= bit
+				break
+			}
+		}
+	}
+	plan = append(plan, settleGroup(group, balances)...)
+
+	return plan
+}
+
+// settleGroup pays off a group of balances by repeatedly having the player
+// who owes the most pay the player owed the most
+func settleGroup(ids []string, balances map[string]int64) []plannedTransfer {
+	remaining := make(map[string]int64, len(ids))
+	for _, id := range ids {
+		remaining[id] = balances[id]
+	}
+
+	var transfers []plannedTransfer
+	for {
+		debtor, creditor := "", ""
+		for _, id := range ids {
+			if remaining[id] < 0 && (debtor == "" || remaining[id] < remaining[debtor]) {
+				debtor = id
+			}
+			if remaining[id] > 0 && (creditor == "" || remaining[id] > remaining[creditor]) {
+				creditor = id
+			}
+		}
+		if debtor == "" || creditor == "" {
+			return transfers
+		}
+
+		cents := min(-remaining[debtor], remaining[creditor])
+		transfers = append(transfers, plannedTransfer{from: debtor, to: creditor, cents: cents})
+		remaining[debtor] += cents
+		remaining[creditor] -= cents
+	}
+}
+
+// toCents converts a dollar amount to whole cents
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// fromCents converts whole cents to a dollar amount
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
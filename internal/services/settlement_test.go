@@ -0,0 +1,131 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestPlanTransfers(t *testing.T) {
+	tests := []struct {
+		name      string
+		balances  []int64
+		transfers int
+	}{
+		{name: "no players", balances: nil, transfers: 0},
+		{name: "one player", balances: []int64{0}, transfers: 0},
+		{name: "all square", balances: []int64{0, 0, 0, 0}, transfers: 0},
+		{name: "two players", balances: []int64{-500, 500}, transfers: 1},
+		{name: "one winner", balances: []int64{-300, -200, -100, 600}, transfers: 3},
+		{name: "two pairs cancel", balances: []int64{-500, -300, 500, 300}, transfers: 2},
+		{name: "pair and triple", balances: []int64{-700, 700, -400, -100, 500}, transfers: 3},
+		{name: "sitting out", balances: []int64{-250, 0, 250, 0}, transfers: 1},
+		{name: "odd cents", balances: []int64{-1, -2, 3}, transfers: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, balances := transferBalances(tt.balances)
+			plan := planTransfers(ids, balances)
+
+			checkTransfersSettle(t, ids, balances, plan)
+			if len(plan) != tt.transfers {
+				t.Errorf("got %d transfers, want %d: %+v", len(plan), tt.transfers, plan)
+			}
+		})
+	}
+}
+
+// TestPlanTransfersMinimal checks the plan against an exhaustive search
+// over random balances
+func TestPlanTransfersMinimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 300; round++ {
+		n := 2 + rng.Intn(6)
+		amounts := make([]int64, n)
+		var total int64
+		for i := 0; i < n-1; i++ {
+			// Small amounts so groups often cancel out
+			amounts[i] = int64(rng.Intn(9)-4) * 100
+			total += amounts[i]
+		}
+		amounts[n-1] = -total
+
+		ids, balances := transferBalances(amounts)
+		plan := planTransfers(ids, balances)
+
+		checkTransfersSettle(t, ids, balances, plan)
+		if want := fewestTransfers(amounts); len(plan) != want {
+			t.Errorf("balances %v: got %d transfers, want %d: %+v", amounts, len(plan), want, plan)
+		}
+	}
+}
+
+func transferBalances(amounts []int64) ([]string, map[string]int64) {
+	ids := make([]string, len(amounts))
+	balances := make(map[string]int64, len(amounts))
+	for i, amount := range amounts {
+		ids[i] = fmt.Sprintf("player_%d", i+1)
+		balances[ids[i]] = amount
+	}
+	return ids, balances
+}
+
+// checkTransfersSettle fails unless every transfer is a positive payment
+// between two players and the plan brings every balance to zero
+func checkTransfersSettle(t *testing.T, ids []string, balances map[string]int64, plan []plannedTransfer) {
+	t.Helper()
+
+	remaining := make(map[string]int64, len(balances))
+	for id, cents := range balances {
+		remaining[id] = cents
+	}
+	for _, transfer := range plan {
+		if transfer.cents <= 0 {
+			t.Errorf("transfer %+v isn't a positive amount", transfer)
+		}
+		if transfer.from == transfer.to {
+			t.Errorf("transfer %+v pays the same player", transfer)
+		}
+		remaining[transfer.from] += transfer.cents
+		remaining[transfer.to] -= transfer.cents
+	}
+	for _, id := range ids {
+		if remaining[id] != 0 {
+			t.Errorf("%s is left with %d cents after %+v", id, remaining[id], plan)
+		}
+	}
+}
+
+// fewestTransfers finds the fewest transfers that settle the balances by
+// trying every way of paying off each debt in turn
+func fewestTransfers(amounts []int64) int {
+	var open []int64
+	for _, amount := range amounts {
+		if amount != 0 {
+			open = append(open, amount)
+		}
+	}
+
+	var search func(start int) int
+	search = func(start int) int {
+		for start < len(open) && open[start] == 0 {
+			start++
+		}
+		if start == len(open) {
+			return 0
+		}
+
+		best := len(open)
+		for i := start + 1; i < len(open); i++ {
+			if open[i]*open[start] >= 0 {
+				continue
+			}
+			open[i] += open[start]
+			best = min(best, 1+search(start+1))
+			open[i] -= open[start]
+		}
+		return best
+	}
+	return search(0)
+}
@@ -2,125 +2,306 @@ package services
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
 
 	"golf-gamez/internal/models"
+	"golf-gamez/pkg/auth"
 	"golf-gamez/pkg/errors"
 )
 
-// SideBetService handles side bet calculations and logic
-type SideBetService struct {
-	db *sql.DB
+const (
+	// roundHoles is the number of holes in a full round
+	roundHoles = 18
+)
+
+// SideBet is implemented by every side bet game. Each side bet is a
+// self-contained module registered with a SideBetRegistry, which game
+// creation, score updates, final results and routing all consult.
+type SideBet interface {
+	// Type returns the identifier used in games.side_bets and in routes
+	Type() models.SideBetType
+
+	// InitializePlayer returns a player's starting calculation data when the game starts
+	InitializePlayer(gameID string, player models.Player) (interface{}, error)
+
+	// ApplyScore updates the side bet for a recorded or edited score
+	ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error
+
+	// Recalculate rebuilds the stored calculations from the recorded scores
+	Recalculate(gameID string) error
+
+	// Standings returns the current standings response for the side bet. It
+	// only reads, since it runs on every leaderboard and spectator view.
+	Standings(gameID string) (interface{}, error)
+
+	// Finalize adds the side bet outcome to the final results when the game
+	// is completed and returns every player's final calculation. It doesn't
+	// write; the calculations are stored in the transaction that completes
+	// the game.
+	Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error)
 }
 
-// NewSideBetService creates a new side bet service
-func NewSideBetService(db *sql.DB) *SideBetService {
-	return &SideBetService{db: db}
+// SideBetAction is an additional endpoint exposed by a side bet, mounted
+// under /side-bets/{bet-type}
+type SideBetAction struct {
+	Method string
+	Path   string
+	Status int // Response status, defaults to 200
+	Handle func(gameID string, body []byte) (interface{}, error)
 }
 
-// GetBestNineStandings returns Best Nine side bet standings
-func (s *SideBetService) GetBestNineStandings(gameID string) (*models.BestNineStandings, error) {
-	// Verify game exists and has Best Nine enabled
-	game, err := s.getGameForSideBet(gameID, models.SideBetBestNine)
-	if err != nil {
-		return nil, err
+// SideBetActionProvider is implemented by side bets with endpoints beyond standings
+type SideBetActionProvider interface {
+	Actions() []SideBetAction
+}
+
+// SideBetStartChecker is implemented by side bets with requirements, such
+// as a number of players, that must be met before the game starts
+type SideBetStartChecker interface {
+	CheckStart(gameID string, players []models.Player) error
+}
+
+// SideBetSettler is implemented by side bets played for money. Settle
+// returns each player's net dollars for a completed game, adding up to
+// zero, or nil when the bet can't be settled yet.
+type SideBetSettler interface {
+	Settle(gameID string) ([]models.SettlementAmount, error)
+}
+
+// SideBetStaker is implemented by side bets with configurable stakes.
+// DefaultStake returns the configured amounts, and the fields it sets are
+// the ones a game's stakes may change.
+type SideBetStaker interface {
+	DefaultStake() models.BetStake
+}
+
+// SideBetRegistry holds the available side bets in registration order
+type SideBetRegistry struct {
+	bets  map[models.SideBetType]SideBet
+	order []models.SideBetType
+}
+
+// NewSideBetRegistry creates a registry containing the given side bets
+func NewSideBetRegistry(bets ...SideBet) *SideBetRegistry {
+	registry := &SideBetRegistry{bets: make(map[models.SideBetType]SideBet)}
+	for _, bet := range bets {
+		registry.Register(bet)
 	}
+	return registry
+}
 
-	// TODO: Implement Best Nine calculation logic
-	// For now, return empty standings
-	standings := &models.BestNineStandings{
-		BetType:         models.SideBetBestNine,
-		Status:          game.Status,
-		HandicapEnabled: game.HandicapEnabled,
-		Standings:       []models.BestNineResult{},
+// Register adds a side bet, replacing any existing bet of the same type
+func (r *SideBetRegistry) Register(bet SideBet) {
+	if _, exists := r.bets[bet.Type()]; !exists {
+		r.order = append(r.order, bet.Type())
 	}
+	r.bets[bet.Type()] = bet
+}
 
-	return standings, nil
+// Get returns the side bet registered for a type
+func (r *SideBetRegistry) Get(sideBetType models.SideBetType) (SideBet, bool) {
+	bet, ok := r.bets[sideBetType]
+	return bet, ok
 }
 
-// GetPuttPuttPokerStatus returns Putt Putt Poker side bet status
-func (s *SideBetService) GetPuttPuttPokerStatus(gameID string) (*models.PuttPuttPokerStatus, error) {
-	// Verify game exists and has Putt Putt Poker enabled
-	game, err := s.getGameForSideBet(gameID, models.SideBetPuttPuttPoker)
-	if err != nil {
-		return nil, err
+// Types returns every registered side bet type
+func (r *SideBetRegistry) Types() []models.SideBetType {
+	return append([]models.SideBetType{}, r.order...)
+}
+
+// All returns every registered side bet
+func (r *SideBetRegistry) All() []SideBet {
+	bets := make([]SideBet, 0, len(r.order))
+	for _, sideBetType := range r.order {
+		bets = append(bets, r.bets[sideBetType])
 	}
+	return bets
+}
 
-	// TODO: Implement Putt Putt Poker logic
-	// For now, return empty status
-	status := &models.PuttPuttPokerStatus{
-		BetType:     models.SideBetPuttPuttPoker,
-		Status:      game.Status,
-		CurrentHole: game.CurrentHole,
-		Players:     []models.PuttPuttPokerResult{},
+// Validate checks that every requested side bet is registered
+func (r *SideBetRegistry) Validate(sideBets []models.SideBetType) error {
+	for _, sideBet := range sideBets {
+		if _, ok := r.bets[sideBet]; !ok {
+			allowed := make([]interface{}, 0, len(r.order))
+			for _, sideBetType := range r.order {
+				allowed = append(allowed, sideBetType)
+			}
+			return errors.ValidationErrorWithAllowedValues("side_bets", string(sideBet), allowed)
+		}
 	}
+	return nil
+}
 
-	return status, nil
+// SideBetService handles side bet calculations and logic
+type SideBetService struct {
+	sideBetStore
+	registry *SideBetRegistry
 }
 
-// DealPokerCards deals final poker cards and determines winner
-func (s *SideBetService) DealPokerCards(gameID string) (*models.PokerDealResult, error) {
-	// Verify game is completed and has Putt Putt Poker enabled
-	game, err := s.getGameForSideBet(gameID, models.SideBetPuttPuttPoker)
-	if err != nil {
-		return nil, err
+// NewSideBetService creates a new side bet service
+func NewSideBetService(db *sql.DB, registry *SideBetRegistry) *SideBetService {
+	return &SideBetService{
+		sideBetStore: sideBetStore{db: db},
+		registry:     registry,
 	}
+}
 
-	if game.Status != models.GameStatusCompleted {
-		return nil, errors.BusinessLogicError(
-			errors.ErrGameNotCompleted,
-			"Cannot deal final cards until game is completed",
-			string(game.Status),
-			string(models.GameStatusCompleted),
-		)
+// Registry returns the side bets available to games
+func (s *SideBetService) Registry() *SideBetRegistry {
+	return s.registry
+}
+
+// IsEnabled reports whether a side bet is enabled for a game
+func (s *SideBetService) IsEnabled(gameID string, sideBetType models.SideBetType) bool {
+	return s.isSideBetEnabled(gameID, sideBetType)
+}
+
+// GetStandings returns the current standings for a side bet
+func (s *SideBetService) GetStandings(gameID string, sideBetType models.SideBetType) (interface{}, error) {
+	bet, ok := s.registry.Get(sideBetType)
+	if !ok {
+		return nil, errors.ResourceNotFoundError("Side bet", string(sideBetType))
 	}
+	return bet.Standings(gameID)
+}
 
-	// Check if cards have already been dealt
-	var count int
-	err = s.db.QueryRow("SELECT COUNT(*) FROM poker_hands WHERE game_id = ?", gameID).Scan(&count)
+// Leaderboard returns the standings of every side bet enabled for a game, or
+// nil when it has none. Side bets that can't be scored yet, such as a Nassau
+// before its matches are set up, are listed as unavailable with the reason.
+func (s *SideBetService) Leaderboard(gameID string) (*models.SideBetLeaderboard, error) {
+	game, err := s.getGameInfo(gameID)
 	if err != nil {
 		return nil, err
 	}
 
-	if count > 0 {
-		return nil, errors.New(errors.ErrCardsAlreadyDealt, "Final cards have already been dealt for this game")
-	}
+	var leaderboard *models.SideBetLeaderboard
+	for _, sideBetType := range game.SideBets {
+		bet, ok := s.registry.Get(sideBetType)
+		if !ok {
+			continue
+		}
+		if leaderboard == nil {
+			leaderboard = &models.SideBetLeaderboard{}
+		}
 
-	// TODO: Implement poker card dealing logic
-	// For now, return empty result
-	result := &models.PokerDealResult{
-		Players: []models.PokerHand{},
+		standings, err := bet.Standings(gameID)
+		if err != nil {
+			apiErr, ok := err.(*errors.APIError)
+			if !ok {
+				return nil, fmt.Errorf("failed to load %s standings: %w", sideBetType, err)
+			}
+			leaderboard.Bets = append(leaderboard.Bets, models.SideBetStatus{
+				BetType: sideBetType,
+				Status:  models.SideBetUnavailable,
+				Code:    string(apiErr.Code),
+				Message: apiErr.Message,
+			})
+			continue
+		}
+
+		leaderboard.Bets = append(leaderboard.Bets, models.SideBetStatus{BetType: sideBetType, Status: models.SideBetAvailable})
+
+		switch standings := standings.(type) {
+		case *models.BestNineStandings:
+			leaderboard.BestNine = standings.Standings
+		case *models.PuttPuttPokerStatus:
+			leaderboard.PuttPuttPoker = standings.Players
+		case *models.SkinsStandings:
+			leaderboard.Skins = standings
+		case *models.NassauStandings:
+			leaderboard.Nassau = standings
+		case *models.WolfStandings:
+			leaderboard.Wolf = standings
+		case *models.BingoBangoBongoStandings:
+			leaderboard.BingoBangoBongo = standings
+		case *models.JunkStandings:
+			leaderboard.Junk = standings
+		case *models.SnakeStandings:
+			leaderboard.Snake = standings
+		case *models.VegasStandings:
+			leaderboard.Vegas = standings
+		}
 	}
 
-	return result, nil
+	return leaderboard, nil
 }
 
 // UpdateSideBetsForScore updates side bet calculations when a score is recorded
 func (s *SideBetService) UpdateSideBetsForScore(gameID, playerID string, score *models.Score) (*models.SideBetUpdates, error) {
-	// TODO: Implement side bet updates
-	// This would be called when a score is recorded to update side bet calculations
+	game, err := s.getGameInfo(gameID)
+	if err != nil {
+		return nil, err
+	}
 
 	updates := &models.SideBetUpdates{}
-
-	// Check if Putt Putt Poker is enabled
-	if s.isSideBetEnabled(gameID, models.SideBetPuttPuttPoker) {
-		puttUpdate, err := s.updatePuttPuttPokerForScore(gameID, playerID, score)
-		if err != nil {
-			return nil, err
+	for _, sideBetType := range game.SideBets {
+		bet, ok := s.registry.Get(sideBetType)
+		if !ok {
+			continue
+		}
+		if err := bet.ApplyScore(gameID, playerID, score, updates); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", sideBetType, err)
 		}
-		updates.PuttPuttPoker = puttUpdate
 	}
 
 	return updates, nil
 }
 
-// Helper methods
+// RecalculateSideBets rebuilds the stored calculations for every side bet on a game
+func (s *SideBetService) RecalculateSideBets(gameID string) error {
+	game, err := s.getGameInfo(gameID)
+	if err != nil {
+		return err
+	}
 
-func (s *SideBetService) getGameForSideBet(gameID string, sideBetType models.SideBetType) (*gameInfo, error) {
+	for _, sideBetType := range game.SideBets {
+		bet, ok := s.registry.Get(sideBetType)
+		if !ok {
+			continue
+		}
+		if err := bet.Recalculate(gameID); err != nil {
+			return fmt.Errorf("failed to recalculate %s: %w", sideBetType, err)
+		}
+	}
+
+	return nil
+}
+
+// sideBetStore provides the data access shared by the side bet implementations
+type sideBetStore struct {
+	db *sql.DB
+}
+
+// getGameForSideBet loads a game and verifies the side bet is enabled for it
+func (s *sideBetStore) getGameForSideBet(gameID string, sideBetType models.SideBetType) (*gameInfo, error) {
+	game, err := s.getGameInfo(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !game.hasSideBet(sideBetType) {
+		return nil, errors.NewWithDetails(
+			errors.ErrSideBetNotEnabled,
+			"Side bet is not enabled for this game",
+			map[string]interface{}{
+				"bet_type": sideBetType,
+			},
+		)
+	}
+
+	return game, nil
+}
+
+// getGameInfo loads the game fields side bets need
+func (s *sideBetStore) getGameInfo(gameID string) (*gameInfo, error) {
 	var game gameInfo
 	var sideBetsJSON string
 
 	query := `
-		SELECT status, handicap_enabled, side_bets, current_hole
+		SELECT status, handicap_enabled, side_bets, current_hole, tiebreak
 		FROM games
 		WHERE id = ?
 	`
@@ -129,6 +310,7 @@ func (s *SideBetService) getGameForSideBet(gameID string, sideBetType models.Sid
 		&game.HandicapEnabled,
 		&sideBetsJSON,
 		&game.CurrentHole,
+		&game.Tiebreak,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -144,44 +326,245 @@ func (s *SideBetService) getGameForSideBet(gameID string, sideBetType models.Sid
 	}
 	game.SideBets = tempGame.SideBets
 
-	// Check if side bet is enabled
-	enabled := false
-	for _, sb := range game.SideBets {
-		if sb == sideBetType {
-			enabled = true
-			break
+	return &game, nil
+}
+
+func (s *sideBetStore) isSideBetEnabled(gameID string, sideBetType models.SideBetType) bool {
+	_, err := s.getGameForSideBet(gameID, sideBetType)
+	return err == nil
+}
+
+// getFinalResults loads the final results stored when a game was completed,
+// nil before then
+func (s *sideBetStore) getFinalResults(gameID string) (*models.FinalResults, error) {
+	var finalResultsJSON sql.NullString
+	if err := s.db.QueryRow("SELECT final_results FROM games WHERE id = ?", gameID).Scan(&finalResultsJSON); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Game", gameID)
 		}
+		return nil, err
 	}
 
-	if !enabled {
-		return nil, errors.NewWithDetails(
-			errors.ErrSideBetNotEnabled,
-			"Side bet is not enabled for this game",
-			map[string]interface{}{
-				"bet_type": sideBetType,
-			},
+	game := &models.Game{}
+	if err := game.UnmarshalFinalResults(finalResultsJSON.String); err != nil {
+		return nil, err
+	}
+	return game.FinalResults, nil
+}
+
+// sideBetCalculation is a player's calculation for a side bet as stored in
+// side_bet_calculations
+type sideBetCalculation struct {
+	playerID string
+	betType  models.SideBetType
+	data     interface{}
+	position int
+	final    bool
+	isWinner bool
+}
+
+// calculationExecer is satisfied by both *sql.DB and *sql.Tx
+type calculationExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// saveSideBetCalculations inserts or updates each player's stored calculation
+func (s *sideBetStore) saveSideBetCalculations(db calculationExecer, gameID string, calculations []sideBetCalculation) error {
+	for _, calculation := range calculations {
+		if err := s.saveSideBetCalculation(db, gameID, calculation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveSideBetCalculation inserts or updates a player's stored calculation for a side bet
+func (s *sideBetStore) saveSideBetCalculation(db calculationExecer, gameID string, calculation sideBetCalculation) error {
+	calculationData, err := json.Marshal(calculation.data)
+	if err != nil {
+		return err
+	}
+
+	sideBetID, err := auth.GenerateSideBetID()
+	if err != nil {
+		return err
+	}
+
+	var finalPosition interface{}
+	if calculation.final {
+		finalPosition = calculation.position
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO side_bet_calculations
+		(id, game_id, player_id, bet_type, calculation_data, current_position, final_position, is_winner, calculated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(player_id, bet_type) DO UPDATE SET
+			calculation_data = excluded.calculation_data,
+			current_position = excluded.current_position,
+			final_position = excluded.final_position,
+			is_winner = excluded.is_winner,
+			calculated_at = excluded.calculated_at
+	`, sideBetID, gameID, calculation.playerID, calculation.betType, string(calculationData), calculation.position, finalPosition, calculation.isWinner, time.Now())
+	return err
+}
+
+// sideBetParticipantSQL matches players, aliased p, taking part in the side
+// bet given as its parameter. Players who never picked their side bets play
+// every side bet in the game.
+const sideBetParticipantSQL = `EXISTS (
+	SELECT 1
+	FROM games pg, json_each(COALESCE(p.side_bets, pg.side_bets)) pb
+	WHERE pg.id = p.game_id AND pb.value = ?
+)`
+
+// getSideBetPlayers loads the players taking part in a side bet
+func (s *sideBetStore) getSideBetPlayers(gameID string, sideBetType models.SideBetType) ([]models.PlayerSummary, error) {
+	return s.queryPlayerSummaries(gameID, `
+		SELECT p.id, p.name, p.handicap
+		FROM players p
+		WHERE p.game_id = ? AND `+sideBetParticipantSQL+`
+		ORDER BY p.position
+	`, gameID, sideBetType)
+}
+
+// getPlayerSummaries loads every player in a game, whichever side bets they play
+func (s *sideBetStore) getPlayerSummaries(gameID string) ([]models.PlayerSummary, error) {
+	return s.queryPlayerSummaries(gameID, `
+		SELECT id, name, handicap
+		FROM players
+		WHERE game_id = ?
+		ORDER BY position
+	`, gameID)
+}
+
+func (s *sideBetStore) queryPlayerSummaries(gameID, query string, args ...interface{}) ([]models.PlayerSummary, error) {
+	handicaps, err := s.getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []models.PlayerSummary
+	for rows.Next() {
+		var player models.PlayerSummary
+		var handicap sql.NullFloat64
+
+		if err := rows.Scan(&player.ID, &player.Name, &handicap); err != nil {
+			return nil, err
+		}
+
+		if handicap.Valid {
+			h := handicap.Float64
+			player.Handicap = &h
+		}
+		handicaps.applyToSummary(&player)
+
+		players = append(players, player)
+	}
+
+	return players, rows.Err()
+}
+
+// playsSideBet reports whether a player takes part in a side bet
+func (s *sideBetStore) playsSideBet(playerID string, sideBetType models.SideBetType) (bool, error) {
+	var count int
+	err := s.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM players p
+		WHERE p.id = ? AND `+sideBetParticipantSQL, playerID, sideBetType).Scan(&count)
+	return count > 0, err
+}
+
+// getPlayerHoleScores loads a player's recorded holes in hole order
+func (s *sideBetStore) getPlayerHoleScores(playerID string) ([]models.HoleScore, error) {
+	rows, err := s.db.Query(`
+		SELECT hole, strokes, par, score_to_par, handicap_strokes
+		FROM scores
+		WHERE player_id = ?
+		ORDER BY hole
+	`, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []models.HoleScore
+	for rows.Next() {
+		var score models.HoleScore
+		err := rows.Scan(
+			&score.Hole,
+			&score.Strokes,
+			&score.Par,
+			&score.ScoreToPar,
+			&score.HandicapStrokes,
 		)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
 	}
 
-	return &game, nil
+	return scores, rows.Err()
 }
 
-func (s *SideBetService) isSideBetEnabled(gameID string, sideBetType models.SideBetType) bool {
-	_, err := s.getGameForSideBet(gameID, sideBetType)
-	return err == nil
+// allocatedStrokes returns the handicap strokes received on a hole with the
+// given stroke index. Strokes beyond 18 wrap around to the hardest holes, and
+// negative (plus) handicaps give strokes back starting with the easiest hole.
+func allocatedStrokes(strokes, ranking int) int {
+	if strokes < 0 {
+		return -allocatedStrokes(-strokes, roundHoles+1-ranking)
+	}
+	allocated := strokes / roundHoles
+	if ranking <= strokes%roundHoles {
+		allocated++
+	}
+	return allocated
 }
 
-func (s *SideBetService) updatePuttPuttPokerForScore(gameID, playerID string, score *models.Score) (*models.PuttPuttPokerUpdate, error) {
-	// TODO: Implement Putt Putt Poker logic for score updates
-	// This would check putts and award cards or apply penalties
+// strokesOffLow returns the handicap strokes each player receives when
+// strokes are given off the lowest handicap among them
+func strokesOffLow(playerIDs []string, byID map[string]models.PlayerSummary) map[string]int {
+	low := math.MaxInt
+	for _, id := range playerIDs {
+		if h := playingHandicap(byID[id]); h < low {
+			low = h
+		}
+	}
 
-	update := &models.PuttPuttPokerUpdate{
-		CardsAwarded:   0,
-		PenaltyApplied: false,
-		TotalCards:     3, // Placeholder
+	strokes := make(map[string]int, len(playerIDs))
+	for _, id := range playerIDs {
+		strokes[id] = playingHandicap(byID[id]) - low
 	}
+	return strokes
+}
 
-	return update, nil
+// roundCurrency rounds a dollar amount to cents
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// splitCurrency splits a dollar amount into n shares that add up to it
+// exactly. Leftover cents go to the first shares.
+func splitCurrency(amount float64, n int) []float64 {
+	if n <= 0 {
+		return nil
+	}
+	cents := int64(math.Round(amount * 100))
+	shares := make([]float64, n)
+	for i := range shares {
+		share := cents / int64(n)
+		if int64(i) < cents%int64(n) {
+			share++
+		}
+		shares[i] = float64(share) / 100
+	}
+	return shares
 }
 
 // gameInfo is a simplified game struct for side bet operations
@@ -190,4 +573,15 @@ type gameInfo struct {
 	HandicapEnabled bool
 	SideBets        []models.SideBetType
 	CurrentHole     *int
-}
\ No newline at end of file
+	Tiebreak        models.TiebreakPolicy
+}
+
+// hasSideBet reports whether the side bet is enabled for the game
+func (g *gameInfo) hasSideBet(sideBetType models.SideBetType) bool {
+	for _, sb := range g.SideBets {
+		if sb == sideBetType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package services
+
+import "testing"
+
+func TestAllocatedStrokes(t *testing.T) {
+	tests := []struct {
+		name    string
+		strokes int
+		want    map[int]int // Strokes received by stroke index
+	}{
+		{name: "scratch", strokes: 0, want: map[int]int{1: 0, 9: 0, 18: 0}},
+		{name: "one stroke", strokes: 1, want: map[int]int{1: 1, 2: 0, 18: 0}},
+		{name: "one a hole", strokes: 18, want: map[int]int{1: 1, 9: 1, 18: 1}},
+		{name: "wraps to the hardest hole", strokes: 19, want: map[int]int{1: 2, 2: 1, 18: 1}},
+		{name: "two a hole", strokes: 36, want: map[int]int{1: 2, 9: 2, 18: 2}},
+		{name: "three a hole", strokes: 54, want: map[int]int{1: 3, 9: 3, 18: 3}},
+		{name: "plus one", strokes: -1, want: map[int]int{18: -1, 17: 0, 1: 0}},
+		{name: "plus three", strokes: -3, want: map[int]int{18: -1, 17: -1, 16: -1, 15: 0, 1: 0}},
+		{name: "plus nineteen", strokes: -19, want: map[int]int{18: -2, 17: -1, 1: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for ranking, want := range tt.want {
+				if got := allocatedStrokes(tt.strokes, ranking); got != want {
+					t.Errorf("allocatedStrokes(%d, %d) = %d, want %d", tt.strokes, ranking, got, want)
+				}
+			}
+
+			// Every stroke lands on exactly one hole
+			total := 0
+			for ranking := 1; ranking <= roundHoles; ranking++ {
+				total += allocatedStrokes(tt.strokes, ranking)
+			}
+			if total != tt.strokes {
+				t.Errorf("allocated %d strokes over the round, want %d", total, tt.strokes)
+			}
+		})
+	}
+}
+
+// TestAllocatedStrokesOrder checks that strokes go to harder holes first
+// and plus handicaps give them back on easier holes first
+func TestAllocatedStrokesOrder(t *testing.T) {
+	for strokes := -roundHoles; strokes <= 3*roundHoles; strokes++ {
+		for ranking := 2; ranking <= roundHoles; ranking++ {
+			harder, easier := allocatedStrokes(strokes, ranking-1), allocatedStrokes(strokes, ranking)
+			if harder < easier {
+				t.Errorf("%d strokes: index %d gets %d, fewer than %d on index %d", strokes, ranking-1, harder, easier, ranking)
+			}
+			if harder-easier > 1 {
+				t.Errorf("%d strokes: index %d gets %d, more than one over %d on index %d", strokes, ranking-1, harder, easier, ranking)
+			}
+		}
+	}
+}
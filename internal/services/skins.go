@@ -0,0 +1,376 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"golf-gamez/internal/models"
+)
+
+// Skins hole statuses
+const (
+	skinsPending = "pending"
+	skinsWon     = "won"
+	skinsCarried = "carried"
+)
+
+// SkinsBet awards each hole to the player with the sole low score. Tied
+// holes carry their skin forward to the next hole.
+type SkinsBet struct {
+	sideBetStore
+	skinValue float64
+}
+
+// NewSkinsBet creates the skins side bet with the dollar value of one skin
+func NewSkinsBet(db *sql.DB, skinValue float64) *SkinsBet {
+	return &SkinsBet{
+		sideBetStore: sideBetStore{db: db},
+		skinValue:    skinValue,
+	}
+}
+
+// Type returns the skins side bet type
+func (s *SkinsBet) Type() models.SideBetType {
+	return models.SideBetSkins
+}
+
+// DefaultStake returns the configured value of one skin
+func (s *SkinsBet) DefaultStake() models.BetStake {
+	value := s.skinValue
+	return models.BetStake{Value: &value}
+}
+
+// InitializePlayer returns an empty skins calculation
+func (s *SkinsBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.SkinsCalculationData{HolesWon: []int{}}, nil
+}
+
+// ApplyScore recalculates skins and reports the outcome of the scored hole
+func (s *SkinsBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	standings, err := s.calculateSkins(gameID)
+	if err != nil {
+		return err
+	}
+	if err := s.saveSideBetCalculations(s.db, gameID, skinsCalculations(standings.Players, false)); err != nil {
+		return err
+	}
+
+	update := &models.SkinsUpdate{
+		Hole:       score.Hole,
+		Status:     skinsPending,
+		CarriedPot: standings.CarriedPot,
+	}
+	for _, hole := range standings.Holes {
+		if hole.Hole != score.Hole {
+			continue
+		}
+		update.Status = hole.Status
+		update.SkinsValue = hole.SkinsValue
+		if hole.Winner != nil {
+			update.WinnerID = &hole.Winner.ID
+		}
+	}
+	updates.Skins = update
+
+	return nil
+}
+
+// Recalculate rebuilds the stored skins totals
+func (s *SkinsBet) Recalculate(gameID string) error {
+	standings, err := s.calculateSkins(gameID)
+	if err != nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, skinsCalculations(standings.Players, false))
+}
+
+// Standings returns the skins standings with per-hole results
+func (s *SkinsBet) Standings(gameID string) (interface{}, error) {
+	// Surface side bet errors such as not enabled as API errors
+	if _, err := s.getGameForSideBet(gameID, models.SideBetSkins); err != nil {
+		return nil, err
+	}
+
+	standings, err := s.calculateSkins(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate skins: %w", err)
+	}
+
+	return standings, nil
+}
+
+// Finalize adds every player's skins total to the final results. Skins
+// still carried after the last hole are not awarded.
+func (s *SkinsBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateSkins(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	results.Skins = standings.Players
+	return skinsCalculations(standings.Players, true), nil
+}
+
+// Settle returns each player's skins winnings less their share of paying
+// for every skin won. Skins still carried after the last hole cost nothing.
+func (s *SkinsBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	standings, err := s.calculateSkins(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	for _, result := range standings.Players {
+		total += result.Winnings
+	}
+	shares := splitCurrency(total, len(standings.Players))
+
+	amounts := make([]models.SettlementAmount, 0, len(standings.Players))
+	for i, result := range standings.Players {
+		amounts = append(amounts, models.SettlementAmount{
+			Player: result.Player,
+			Amount: roundCurrency(result.Winnings - shares[i]),
+		})
+	}
+	return amounts, nil
+}
+
+// Projection plays skins forward from each player's holes
+func (s *SkinsBet) Projection(gameID string, rounds []*projectedRound) (*roundProjection, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetSkins)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetSkins)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := &roundProjection{
+		result:   string(models.SideBetSkins),
+		rounds:   sideBetRounds(rounds, players),
+		highWins: true,
+		score: func(cards []map[int]roundHole) []float64 {
+			return countSkins(cards, game.HandicapEnabled)
+		},
+		format: func(value float64) string {
+			if value == 1 {
+				return "1 skin"
+			}
+			return fmt.Sprintf("%d skins", int(value))
+		},
+	}
+	if game.HandicapEnabled {
+		projection.level = func(round *projectedRound, hole int) int {
+			return round.received[hole]
+		}
+	}
+	return projection, nil
+}
+
+// countSkins counts the skins each card wins. Like the standings, holes are
+// decided in order and nothing after the first incomplete hole is settled.
+func countSkins(cards []map[int]roundHole, handicapEnabled bool) []float64 {
+	skins := make([]float64, len(cards))
+	carried := 0
+	for hole := 1; hole <= roundHoles; hole++ {
+		low, winner := 0, -1
+		for i, card := range cards {
+			score, ok := card[hole]
+			if !ok {
+				return skins
+			}
+
+			strokes := score.strokes
+			if handicapEnabled {
+				strokes = score.par + models.CalculateEffectiveScore(score.strokes, score.par, score.handicapStrokes)
+			}
+			switch {
+			case i == 0 || strokes < low:
+				low, winner = strokes, i
+			case strokes == low:
+				winner = -1
+			}
+		}
+
+		value := carried + 1
+		if winner < 0 {
+			carried = value
+			continue
+		}
+		skins[winner] += float64(value)
+		carried = 0
+	}
+	return skins
+}
+
+// skinsScore is a recorded hole used for skins
+type skinsScore struct {
+	playerID string
+	strokes  int
+}
+
+// calculateSkins walks the holes in order, awarding each hole every player
+// has scored. Holes are decided in order, so nothing after the first
+// incomplete hole is settled.
+func (s *SkinsBet) calculateSkins(gameID string) (*models.SkinsStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetSkins)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetSkins)
+	if err != nil {
+		return nil, err
+	}
+
+	holeScores, err := s.getSkinsScores(gameID, game.HandicapEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	stake, err := s.getBetStake(gameID, models.SideBetSkins, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.SkinsResult, len(players))
+	index := make(map[string]int, len(players))
+	for i, player := range players {
+		results[i] = models.SkinsResult{Player: player, HolesWon: []int{}}
+		index[player.ID] = i
+	}
+
+	standings := &models.SkinsStandings{
+		BetType:         models.SideBetSkins,
+		Status:          game.Status,
+		HandicapEnabled: game.HandicapEnabled,
+		SkinValue:       stake.value,
+		Holes:           make([]models.SkinsHoleResult, 0, roundHoles),
+	}
+
+	carried := 0
+	decided := true
+	for hole := 1; hole <= roundHoles; hole++ {
+		value := carried + 1
+		result := models.SkinsHoleResult{
+			Hole:       hole,
+			Status:     skinsPending,
+			SkinsValue: value,
+			PotValue:   roundCurrency(float64(value) * stake.value),
+		}
+
+		scores := holeScores[hole]
+		if !decided || len(players) == 0 || len(scores) < len(players) {
+			decided = false
+			standings.Holes = append(standings.Holes, result)
+			continue
+		}
+
+		low := scores[0]
+		tied := false
+		for _, score := range scores[1:] {
+			switch {
+			case score.strokes < low.strokes:
+				low = score
+				tied = false
+			case score.strokes == low.strokes:
+				tied = true
+			}
+		}
+
+		if tied {
+			result.Status = skinsCarried
+			carried = value
+		} else {
+			winner := &results[index[low.playerID]]
+			player := winner.Player
+			strokes := low.strokes
+			result.Status = skinsWon
+			result.Winner = &player
+			result.Score = &strokes
+			winner.Skins += value
+			winner.HolesWon = append(winner.HolesWon, hole)
+			carried = 0
+		}
+
+		standings.Holes = append(standings.Holes, result)
+	}
+
+	for i := range results {
+		results[i].Winnings = roundCurrency(float64(results[i].Skins) * stake.value)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Skins > results[j].Skins
+	})
+	for i := range results {
+		if i > 0 && results[i].Skins == results[i-1].Skins {
+			results[i].Position = results[i-1].Position
+			continue
+		}
+		results[i].Position = i + 1
+	}
+
+	standings.Players = results
+	standings.CarriedPot = carried
+	standings.CarriedValue = roundCurrency(float64(carried) * stake.value)
+
+	return standings, nil
+}
+
+// getSkinsScores loads every recorded hole keyed by hole number. Net scores
+// come from the stored effective score when handicaps are enabled.
+func (s *SkinsBet) getSkinsScores(gameID string, handicapEnabled bool) (map[int][]skinsScore, error) {
+	rows, err := s.db.Query(`
+		SELECT s.player_id, s.hole, s.strokes, s.par, s.effective_score
+		FROM scores s
+		JOIN players p ON p.id = s.player_id
+		WHERE s.game_id = ? AND `+sideBetParticipantSQL+`
+		ORDER BY s.hole, p.position
+	`, gameID, models.SideBetSkins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := make(map[int][]skinsScore)
+	for rows.Next() {
+		var playerID string
+		var hole, strokes, par, effectiveScore int
+		if err := rows.Scan(&playerID, &hole, &strokes, &par, &effectiveScore); err != nil {
+			return nil, err
+		}
+
+		if handicapEnabled {
+			strokes = par + effectiveScore
+		}
+		scores[hole] = append(scores[hole], skinsScore{playerID: playerID, strokes: strokes})
+	}
+
+	return scores, rows.Err()
+}
+
+// skinsCalculations builds the stored skins totals
+func skinsCalculations(results []models.SkinsResult, final bool) []sideBetCalculation {
+	calculations := make([]sideBetCalculation, 0, len(results))
+	for _, result := range results {
+		data := models.SkinsCalculationData{
+			Skins:    result.Skins,
+			HolesWon: result.HolesWon,
+			Winnings: result.Winnings,
+		}
+		isWinner := final && result.Position == 1 && result.Skins > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: result.Player.ID,
+			betType:  models.SideBetSkins,
+			data:     data,
+			position: result.Position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+	return calculations
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+
+	"golf-gamez/internal/models"
+)
+
+func TestSkinsCarryover(t *testing.T) {
+	tests := []struct {
+		name       string
+		holes      [][3]int // Ann's, Bob's and Cat's strokes on each hole from the first
+		statuses   []string
+		skins      [3]int
+		carriedPot int
+	}{
+		{
+			name:     "won outright",
+			holes:    [][3]int{{3, 4, 4}},
+			statuses: []string{skinsWon},
+			skins:    [3]int{1, 0, 0},
+		},
+		{
+			name:     "tie carries into the next hole",
+			holes:    [][3]int{{4, 4, 5}, {4, 3, 5}},
+			statuses: []string{skinsCarried, skinsWon},
+			skins:    [3]int{0, 2, 0},
+		},
+		{
+			name:     "carries over several holes",
+			holes:    [][3]int{{4, 4, 5}, {5, 5, 5}, {4, 5, 3}},
+			statuses: []string{skinsCarried, skinsCarried, skinsWon},
+			skins:    [3]int{0, 0, 3},
+		},
+		{
+			name:     "carry starts again after a win",
+			holes:    [][3]int{{4, 4, 5}, {3, 4, 4}, {4, 4, 4}, {5, 4, 5}},
+			statuses: []string{skinsCarried, skinsWon, skinsCarried, skinsWon},
+			skins:    [3]int{2, 2, 0},
+		},
+		{
+			name:       "carry rides on the next hole",
+			holes:      [][3]int{{3, 4, 4}, {4, 4, 4}, {5, 5, 4}, {4, 4, 5}},
+			statuses:   []string{skinsWon, skinsCarried, skinsWon, skinsCarried, skinsPending},
+			skins:      [3]int{1, 0, 2},
+			carriedPot: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			skins := NewSkinsBet(db, 2)
+			registry := NewSideBetRegistry(skins)
+			games := NewGameService(db, registry)
+			players := NewPlayerService(db)
+			sideBets := NewSideBetService(db, registry)
+			scores := NewScoreService(db, sideBets)
+
+			game, err := games.CreateGame(&models.CreateGameRequest{
+				Course:   "diamond-run",
+				SideBets: []models.SideBetType{models.SideBetSkins},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var playerIDs []string
+			for _, name := range []string{"Ann", "Bob", "Cat"} {
+				handicap := 0.0
+				player, err := players.AddPlayer(game.ID, &models.CreatePlayerRequest{Name: name, Handicap: &handicap})
+				if err != nil {
+					t.Fatal(err)
+				}
+				playerIDs = append(playerIDs, player.ID)
+			}
+			if _, err := games.StartGame(game.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			cards := make([]map[int]roundHole, len(playerIDs))
+			for i := range cards {
+				cards[i] = make(map[int]roundHole)
+			}
+			for hole, strokes := range tt.holes {
+				for i, playerID := range playerIDs {
+					score, err := scores.RecordScore(game.ID, playerID, &models.ScoreRequest{Hole: hole + 1, Strokes: strokes[i], Putts: 2})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := sideBets.UpdateSideBetsForScore(game.ID, playerID, score); err != nil {
+						t.Fatal(err)
+					}
+					cards[i][hole+1] = roundHole{strokes: score.Strokes, par: score.Par}
+				}
+			}
+
+			standings, err := skins.calculateSkins(game.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, status := range tt.statuses {
+				if got := standings.Holes[i].Status; got != status {
+					t.Errorf("hole %d status = %s, want %s", i+1, got, status)
+				}
+			}
+
+			won := make(map[string]int, len(standings.Players))
+			for _, result := range standings.Players {
+				won[result.Player.ID] = result.Skins
+			}
+			projected := countSkins(cards, false)
+			for i, playerID := range playerIDs {
+				if won[playerID] != tt.skins[i] {
+					t.Errorf("player %d won %d skins, want %d", i, won[playerID], tt.skins[i])
+				}
+				if int(projected[i]) != tt.skins[i] {
+					t.Errorf("player %d projected %.0f skins, want %d", i, projected[i], tt.skins[i])
+				}
+			}
+
+			if standings.CarriedPot != tt.carriedPot {
+				t.Errorf("carried pot = %d, want %d", standings.CarriedPot, tt.carriedPot)
+			}
+			if next := standings.Holes[len(tt.holes)].SkinsValue; next != tt.carriedPot+1 {
+				t.Errorf("next hole is worth %d skins, want %d", next, tt.carriedPot+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"database/sql"
+
+	"golf-gamez/internal/models"
+)
+
+// snakePutts is the putt count that takes the snake
+const snakePutts = 3
+
+// SnakeBet passes the snake to whoever last three-putted. The pot grows
+// each time the snake changes hands, and whoever holds it at the end of the
+// round pays the pot to the other players.
+type SnakeBet struct {
+	sideBetStore
+	passValue float64
+}
+
+// NewSnakeBet creates the snake side bet with the dollars added to the pot
+// on each pass
+func NewSnakeBet(db *sql.DB, passValue float64) *SnakeBet {
+	return &SnakeBet{
+		sideBetStore: sideBetStore{db: db},
+		passValue:    passValue,
+	}
+}
+
+// Type returns the snake side bet type
+func (s *SnakeBet) Type() models.SideBetType {
+	return models.SideBetSnake
+}
+
+// DefaultStake returns the configured dollars added to the pot on each pass
+func (s *SnakeBet) DefaultStake() models.BetStake {
+	value := s.passValue
+	return models.BetStake{Value: &value}
+}
+
+// InitializePlayer returns an empty snake calculation
+func (s *SnakeBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.SnakeCalculationData{}, nil
+}
+
+// ApplyScore replays the snake and reports who holds it after the score.
+// The snake is replayed from every recorded score, so edits to earlier
+// holes are reflected.
+func (s *SnakeBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	standings, err := s.calculateSnake(gameID)
+	if err != nil {
+		return err
+	}
+	if err := s.saveSideBetCalculations(s.db, gameID, snakeCalculations(standings, false)); err != nil {
+		return err
+	}
+
+	update := &models.SnakeUpdate{Pot: standings.Pot}
+	if standings.Holder != nil {
+		update.HolderID = &standings.Holder.ID
+	}
+	for _, pass := range standings.Passes {
+		if pass.Hole == score.Hole && pass.Player.ID == playerID {
+			update.Passed = true
+		}
+	}
+	updates.Snake = update
+
+	return nil
+}
+
+// Recalculate rebuilds the stored snake results
+func (s *SnakeBet) Recalculate(gameID string) error {
+	standings, err := s.calculateSnake(gameID)
+	if err != nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, snakeCalculations(standings, false))
+}
+
+// Standings returns the snake holder, pot and every pass
+func (s *SnakeBet) Standings(gameID string) (interface{}, error) {
+	standings, err := s.calculateSnake(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// Finalize adds the final holder and payout to the final results
+func (s *SnakeBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateSnake(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	results.Snake = &models.SnakeSettlement{
+		Holder:     standings.Holder,
+		Pot:        standings.Pot,
+		Settlement: standings.Settlement,
+	}
+	return snakeCalculations(standings, true), nil
+}
+
+// Settle returns what the holder pays and each other player collects
+func (s *SnakeBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	standings, err := s.calculateSnake(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make([]models.SettlementAmount, 0, len(standings.Settlement))
+	for _, payout := range standings.Settlement {
+		amounts = append(amounts, models.SettlementAmount{Player: payout.Player, Amount: payout.Amount})
+	}
+	return amounts, nil
+}
+
+// calculateSnake replays every three-putt in hole order. When more than
+// one player three-putts the same hole, the snake ends with whoever is
+// later in the tee order.
+func (s *SnakeBet) calculateSnake(gameID string) (*models.SnakeStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetSnake)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetSnake)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.PlayerSummary, len(players))
+	for _, player := range players {
+		byID[player.ID] = player
+	}
+
+	stake, err := s.getBetStake(gameID, models.SideBetSnake, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT s.player_id, s.hole, s.putts
+		FROM scores s
+		JOIN players p ON p.id = s.player_id
+		WHERE s.game_id = ? AND s.putts >= ? AND `+sideBetParticipantSQL+`
+		ORDER BY s.hole, p.position
+	`, gameID, snakePutts, models.SideBetSnake)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	standings := &models.SnakeStandings{
+		BetType:   models.SideBetSnake,
+		Status:    game.Status,
+		PassValue: stake.value,
+		Passes:    []models.SnakePass{},
+	}
+
+	for rows.Next() {
+		var playerID string
+		var hole, putts int
+		if err := rows.Scan(&playerID, &hole, &putts); err != nil {
+			return nil, err
+		}
+
+		if standings.Holder != nil && standings.Holder.ID == playerID {
+			continue
+		}
+
+		player := byID[playerID]
+		standings.Holder = &player
+		standings.Pot = roundCurrency(standings.Pot + stake.value)
+		standings.Passes = append(standings.Passes, models.SnakePass{
+			Hole:   hole,
+			Player: player,
+			Putts:  putts,
+			Pot:    standings.Pot,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The holder pays the pot, split evenly among everyone else
+	var shares []float64
+	if standings.Holder != nil {
+		shares = splitCurrency(standings.Pot, len(players)-1)
+	}
+	standings.Settlement = make([]models.SnakePayout, 0, len(players))
+	for _, player := range players {
+		payout := models.SnakePayout{Player: player}
+		switch {
+		case len(shares) == 0:
+		case player.ID == standings.Holder.ID:
+			payout.Amount = -standings.Pot
+		default:
+			payout.Amount = shares[0]
+			shares = shares[1:]
+		}
+		standings.Settlement = append(standings.Settlement, payout)
+	}
+
+	return standings, nil
+}
+
+// snakeCalculations builds each player's stored snake result. The holder
+// is ranked last.
+func snakeCalculations(standings *models.SnakeStandings, final bool) []sideBetCalculation {
+	passes := make(map[string]int)
+	for _, pass := range standings.Passes {
+		passes[pass.Player.ID]++
+	}
+
+	calculations := make([]sideBetCalculation, 0, len(standings.Settlement))
+	for _, payout := range standings.Settlement {
+		holder := standings.Holder != nil && standings.Holder.ID == payout.Player.ID
+		position := 1
+		if holder {
+			position = 2
+		}
+
+		data := models.SnakeCalculationData{
+			Holder: holder,
+			Passes: passes[payout.Player.ID],
+			Amount: payout.Amount,
+		}
+		isWinner := final && payout.Amount > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: payout.Player.ID,
+			betType:  models.SideBetSnake,
+			data:     data,
+			position: position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+	return calculations
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golf-gamez/internal/models"
+)
+
+func TestSnakeReplaysEditedScores(t *testing.T) {
+	type putt struct {
+		player int // Index into Ann, Bob and Cat
+		hole   int
+	}
+	type edit struct {
+		player, hole, putts int
+	}
+
+	tests := []struct {
+		name       string
+		threePutts []putt // Three-putts as first recorded over four holes
+		edits      []edit
+		holder     int // -1 when nobody holds the snake
+		passes     []int
+	}{
+		{
+			name:       "last three-putt taken back",
+			threePutts: []putt{{0, 1}, {1, 3}},
+			edits:      []edit{{player: 1, hole: 3, putts: 2}},
+			holder:     0,
+			passes:     []int{1},
+		},
+		{
+			name:       "first three-putt taken back",
+			threePutts: []putt{{0, 1}, {1, 3}},
+			edits:      []edit{{player: 0, hole: 1, putts: 2}},
+			holder:     1,
+			passes:     []int{3},
+		},
+		{
+			name:       "earlier three-putt added",
+			threePutts: []putt{{0, 1}, {1, 3}},
+			edits:      []edit{{player: 2, hole: 2, putts: 3}},
+			holder:     1,
+			passes:     []int{1, 2, 3},
+		},
+		{
+			name:       "holder's later three-putt doesn't pass again",
+			threePutts: []putt{{0, 1}, {1, 3}},
+			edits:      []edit{{player: 1, hole: 2, putts: 3}},
+			holder:     1,
+			passes:     []int{1, 2},
+		},
+		{
+			name:       "every three-putt taken back",
+			threePutts: []putt{{0, 1}},
+			edits:      []edit{{player: 0, hole: 1, putts: 1}},
+			holder:     -1,
+			passes:     []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			snake := NewSnakeBet(db, 1)
+			registry := NewSideBetRegistry(snake)
+			games := NewGameService(db, registry)
+			players := NewPlayerService(db)
+			sideBets := NewSideBetService(db, registry)
+			scores := NewScoreService(db, sideBets)
+
+			game, err := games.CreateGame(&models.CreateGameRequest{
+				Course:   "diamond-run",
+				SideBets: []models.SideBetType{models.SideBetSnake},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var playerIDs []string
+			for _, name := range []string{"Ann", "Bob", "Cat"} {
+				handicap := 0.0
+				player, err := players.AddPlayer(game.ID, &models.CreatePlayerRequest{Name: name, Handicap: &handicap})
+				if err != nil {
+					t.Fatal(err)
+				}
+				playerIDs = append(playerIDs, player.ID)
+			}
+			if _, err := games.StartGame(game.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			for hole := 1; hole <= 4; hole++ {
+				for i, playerID := range playerIDs {
+					putts := 2
+					for _, threePutt := range tt.threePutts {
+						if threePutt == (putt{i, hole}) {
+							putts = 3
+						}
+					}
+					score, err := scores.RecordScore(game.ID, playerID, &models.ScoreRequest{Hole: hole, Strokes: 5, Putts: putts})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, err := sideBets.UpdateSideBetsForScore(game.ID, playerID, score); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			for _, e := range tt.edits {
+				putts := e.putts
+				score, err := scores.UpdateScore(game.ID, playerIDs[e.player], e.hole, &models.UpdateScoreRequest{Putts: &putts})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := sideBets.UpdateSideBetsForScore(game.ID, playerIDs[e.player], score); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			standings, err := snake.calculateSnake(game.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			holder := -1
+			for i, playerID := range playerIDs {
+				if standings.Holder != nil && standings.Holder.ID == playerID {
+					holder = i
+				}
+			}
+			if holder != tt.holder {
+				t.Errorf("holder = %d, want %d", holder, tt.holder)
+			}
+
+			passes := make([]int, 0, len(standings.Passes))
+			for _, pass := range standings.Passes {
+				passes = append(passes, pass.Hole)
+			}
+			if len(passes) != len(tt.passes) {
+				t.Fatalf("passes on holes %v, want %v", passes, tt.passes)
+			}
+			for i := range passes {
+				if passes[i] != tt.passes[i] {
+					t.Fatalf("passes on holes %v, want %v", passes, tt.passes)
+				}
+			}
+			if want := float64(len(tt.passes)); standings.Pot != want {
+				t.Errorf("pot = %.2f, want %.2f", standings.Pot, want)
+			}
+
+			// The stored calculations follow the replay too
+			for i, playerID := range playerIDs {
+				var data string
+				err := db.QueryRow(
+					"SELECT calculation_data FROM side_bet_calculations WHERE player_id = ? AND bet_type = ?",
+					playerID, models.SideBetSnake,
+				).Scan(&data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var calculation models.SnakeCalculationData
+				if err := json.Unmarshal([]byte(data), &calculation); err != nil {
+					t.Fatal(err)
+				}
+				if calculation.Holder != (i == tt.holder) {
+					t.Errorf("player %d stored as holder = %t, want %t", i, calculation.Holder, i == tt.holder)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+const (
+	// maxStakeAmount is the largest dollar amount a stake may be set to
+	maxStakeAmount = 10000
+	// maxPayoutPlaces is the most finishing places a pot can be split between
+	maxPayoutPlaces = 4
+)
+
+// betStake holds the amounts a side bet is played for in one game. In a
+// points-only game every amount is zero.
+type betStake struct {
+	buyIn       float64
+	value       float64
+	penalty     float64
+	payoutSplit []int
+	pointsOnly  bool
+	stored      bool // The game saved its stakes, rather than predating them
+}
+
+// validateStakes checks the stakes requested for a game and returns them
+// merged over the defaults of every side bet the game plays for money
+func validateStakes(req *models.Stakes, sideBets []models.SideBetType, registry *SideBetRegistry) (*models.Stakes, error) {
+	stakes := &models.Stakes{}
+	if req == nil {
+		return mergeStakes(stakes, sideBets, registry), nil
+	}
+	stakes.PointsOnly = req.PointsOnly
+
+	enabled := make(map[models.SideBetType]bool, len(sideBets))
+	for _, sideBet := range sideBets {
+		enabled[sideBet] = true
+	}
+
+	requested := make([]string, 0, len(req.Bets))
+	for betType := range req.Bets {
+		requested = append(requested, string(betType))
+	}
+	sort.Strings(requested)
+
+	for _, name := range requested {
+		betType := models.SideBetType(name)
+		if !enabled[betType] {
+			return nil, errors.ValidationError("stakes.bets", name, "must be one of the game's side bets")
+		}
+		bet, _ := registry.Get(betType)
+		staker, ok := bet.(SideBetStaker)
+		if !ok {
+			return nil, errors.ValidationError("stakes.bets", name, "is played for points")
+		}
+		if err := validateBetStake(name, req.Bets[betType], staker.DefaultStake()); err != nil {
+			return nil, err
+		}
+	}
+
+	stakes.Bets = req.Bets
+	return mergeStakes(stakes, sideBets, registry), nil
+}
+
+// validateBetStake checks one side bet's stake. A bet's defaults set the
+// fields it uses; the rest can't be given.
+func validateBetStake(betType string, stake, defaults models.BetStake) error {
+	amounts := []struct {
+		field   string
+		value   *float64
+		allowed bool
+	}{
+		{"buy_in", stake.BuyIn, defaults.BuyIn != nil},
+		{"value", stake.Value, defaults.Value != nil},
+		{"penalty", stake.Penalty, defaults.Penalty != nil},
+	}
+
+	for _, amount := range amounts {
+		if amount.value == nil {
+			continue
+		}
+		field := fmt.Sprintf("stakes.bets.%s.%s", betType, amount.field)
+		if !amount.allowed {
+			return errors.ValidationError(field, fmt.Sprintf("%.2f", *amount.value), "is not used by "+betType)
+		}
+		if err := checkStakeAmount(field, *amount.value); err != nil {
+			return err
+		}
+	}
+
+	if stake.PayoutSplit == nil {
+		return nil
+	}
+	field := fmt.Sprintf("stakes.bets.%s.payout_split", betType)
+	if defaults.PayoutSplit == nil {
+		return errors.ValidationError(field, fmt.Sprintf("%v", stake.PayoutSplit), "is not used by "+betType)
+	}
+	return validatePayoutSplit(field, stake.PayoutSplit)
+}
+
+// checkStakeAmount checks a dollar amount is in range and in whole cents
+func checkStakeAmount(field string, amount float64) error {
+	value := fmt.Sprintf("%.2f", amount)
+	if amount < 0 || amount > maxStakeAmount {
+		return errors.ValidationError(field, value, fmt.Sprintf("must be between 0 and %d", maxStakeAmount))
+	}
+	if math.Abs(amount*100-math.Round(amount*100)) > 1e-6 {
+		return errors.ValidationError(field, fmt.Sprintf("%g", amount), "must be in whole cents")
+	}
+	return nil
+}
+
+// validatePayoutSplit checks a split pays whole percentages to at most four
+// places, adding up to 100, with no place paid more than the one above it
+func validatePayoutSplit(field string, split []int) error {
+	value := fmt.Sprintf("%v", split)
+	if len(split) == 0 || len(split) > maxPayoutPlaces {
+		return errors.ValidationError(field, value, fmt.Sprintf("must pay between 1 and %d places", maxPayoutPlaces))
+	}
+
+	total := 0
+	for i, percent := range split {
+		if percent <= 0 {
+			return errors.ValidationError(field, value, "every place must be paid a positive percentage")
+		}
+		if i > 0 && percent > split[i-1] {
+			return errors.ValidationError(field, value, "must not pay a place more than the place above it")
+		}
+		total += percent
+	}
+	if total != 100 {
+		return errors.ValidationError(field, value, "must add up to 100")
+	}
+	return nil
+}
+
+// mergeStakes fills in the defaults for every side bet a game plays for
+// money. Side bets played for points have no stake.
+func mergeStakes(stakes *models.Stakes, sideBets []models.SideBetType, registry *SideBetRegistry) *models.Stakes {
+	merged := &models.Stakes{
+		PointsOnly: stakes.PointsOnly,
+		Bets:       make(map[models.SideBetType]models.BetStake),
+	}
+
+	for _, sideBet := range sideBets {
+		bet, ok := registry.Get(sideBet)
+		if !ok {
+			continue
+		}
+		staker, ok := bet.(SideBetStaker)
+		if !ok {
+			continue
+		}
+		merged.Bets[sideBet] = mergeBetStake(stakes.Bets[sideBet], staker.DefaultStake())
+	}
+
+	return merged
+}
+
+// mergeBetStake fills the fields a side bet uses from its defaults
+func mergeBetStake(stake, defaults models.BetStake) models.BetStake {
+	merged := models.BetStake{}
+	if defaults.BuyIn != nil {
+		merged.BuyIn = pickAmount(stake.BuyIn, defaults.BuyIn)
+	}
+	if defaults.Value != nil {
+		merged.Value = pickAmount(stake.Value, defaults.Value)
+	}
+	if defaults.Penalty != nil {
+		merged.Penalty = pickAmount(stake.Penalty, defaults.Penalty)
+	}
+	if defaults.PayoutSplit != nil {
+		merged.PayoutSplit = defaults.PayoutSplit
+		if stake.PayoutSplit != nil {
+			merged.PayoutSplit = stake.PayoutSplit
+		}
+	}
+	return merged
+}
+
+// pickAmount returns a copy of the amount, or of the default when unset
+func pickAmount(amount, defaultAmount *float64) *float64 {
+	if amount == nil {
+		amount = defaultAmount
+	}
+	picked := *amount
+	return &picked
+}
+
+// getStakes loads the stakes saved for a game, nil for games that predate them
+func (s *sideBetStore) getStakes(gameID string) (*models.Stakes, error) {
+	var stakesJSON sql.NullString
+	err := s.db.QueryRow("SELECT stakes FROM games WHERE id = ?", gameID).Scan(&stakesJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Game", gameID)
+		}
+		return nil, err
+	}
+
+	game := &models.Game{}
+	if err := game.UnmarshalStakes(stakesJSON.String); err != nil {
+		return nil, err
+	}
+	return game.Stakes, nil
+}
+
+// getBetStake returns the amounts a side bet is played for in a game, using
+// the bet's defaults for anything the game didn't save
+func (s *sideBetStore) getBetStake(gameID string, betType models.SideBetType, defaults models.BetStake) (betStake, error) {
+	stakes, err := s.getStakes(gameID)
+	if err != nil {
+		return betStake{}, err
+	}
+
+	stake := mergeBetStake(models.BetStake{}, defaults)
+	resolved := betStake{}
+	if stakes != nil {
+		stake = mergeBetStake(stakes.Bets[betType], defaults)
+		resolved.pointsOnly = stakes.PointsOnly
+		resolved.stored = true
+	}
+
+	resolved.payoutSplit = stake.PayoutSplit
+	if resolved.pointsOnly {
+		return resolved, nil
+	}
+	if stake.BuyIn != nil {
+		resolved.buyIn = *stake.BuyIn
+	}
+	if stake.Value != nil {
+		resolved.value = *stake.Value
+	}
+	if stake.Penalty != nil {
+		resolved.penalty = *stake.Penalty
+	}
+	return resolved, nil
+}
+
+// setBetStakeValue saves a side bet's value to the game's stakes, so amounts
+// given in a side bet's setup are the ones its standings and settlement use
+func (s *sideBetStore) setBetStakeValue(gameID string, betType models.SideBetType, value float64) error {
+	stakes, err := s.getStakes(gameID)
+	if err != nil {
+		return err
+	}
+	if stakes == nil {
+		stakes = &models.Stakes{}
+	}
+	if stakes.Bets == nil {
+		stakes.Bets = make(map[models.SideBetType]models.BetStake)
+	}
+
+	stake := stakes.Bets[betType]
+	stake.Value = &value
+	stakes.Bets[betType] = stake
+
+	stakesJSON, err := (&models.Game{Stakes: stakes}).MarshalStakes()
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("UPDATE games SET stakes = ? WHERE id = ?", stakesJSON, gameID)
+	return err
+}
+
+// payoutShares splits a pot between players by finishing position. Players
+// tied on a position pool the percentages of the places they fill and share
+// them evenly. Percentages for places beyond the field go to first place, and
+// leftover cents to the earliest shares.
+func payoutShares(pot float64, split []int, positions []int) []float64 {
+	shares := make([]float64, len(positions))
+	if len(positions) == 0 {
+		return shares
+	}
+
+	tied := make(map[int][]int)
+	var places []int
+	for i, position := range positions {
+		if _, ok := tied[position]; !ok {
+			places = append(places, position)
+		}
+		tied[position] = append(tied[position], i)
+	}
+	sort.Ints(places)
+
+	percents := make([]int, len(places))
+	claimed := 0
+	for g, position := range places {
+		for place := position; place < position+len(tied[position]); place++ {
+			if place <= len(split) {
+				percents[g] += split[place-1]
+			}
+		}
+		claimed += percents[g]
+	}
+	percents[0] += 100 - claimed
+
+	cents := int64(math.Round(pot * 100))
+	placeCents := make([]int64, len(places))
+	paid := int64(0)
+	for g := range places {
+		placeCents[g] = cents * int64(percents[g]) / 100
+		paid += placeCents[g]
+	}
+	placeCents[0] += cents - paid
+
+	for g, position := range places {
+		for k, share := range splitCurrency(float64(placeCents[g])/100, len(tied[position])) {
+			shares[tied[position][k]] = share
+		}
+	}
+	return shares
+}
@@ -0,0 +1,575 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/auth"
+	"golf-gamez/pkg/errors"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxTeamPlayers is the largest team allowed
+const maxTeamPlayers = 2
+
+// TeamService handles team scores and the team views of a game
+type TeamService struct {
+	db     *sql.DB
+	scores *ScoreService
+}
+
+// NewTeamService creates a new team service
+func NewTeamService(db *sql.DB) *TeamService {
+	return &TeamService{db: db, scores: NewScoreService(db, nil)}
+}
+
+// GetTeams returns a game's teams with their players
+func (s *TeamService) GetTeams(gameID string) (*models.TeamsResponse, error) {
+	format, err := s.getTeamFormat(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.getTeams(gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	return &models.TeamsResponse{
+		TeamFormat: format,
+		Teams:      teams,
+		TotalCount: len(teams),
+	}, nil
+}
+
+// RecordTeamScore records a scramble team's score for a hole
+func (s *TeamService) RecordTeamScore(gameID, teamID string, req *models.ScoreRequest) (*models.TeamScore, error) {
+	if err := s.scores.validateScoreRequest(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTeamScoreRules(gameID, teamID); err != nil {
+		return nil, err
+	}
+
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM team_scores WHERE team_id = ? AND hole = ?", teamID, req.Hole).Scan(&count)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New(errors.ErrScoreAlreadyExists, "Score for this hole has already been recorded")
+	}
+
+	par, err := s.scores.getCourseHolePar(gameID, req.Hole)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hole par: %w", err)
+	}
+
+	scoreID, err := auth.GenerateTeamScoreID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate team score ID: %w", err)
+	}
+
+	score := &models.TeamScore{
+		ID:         scoreID,
+		TeamID:     teamID,
+		GameID:     gameID,
+		Hole:       req.Hole,
+		Strokes:    req.Strokes,
+		Putts:      req.Putts,
+		Par:        par,
+		ScoreToPar: models.FormatScoreToPar(req.Strokes, par),
+		CreatedAt:  time.Now(),
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO team_scores (id, team_id, game_id, hole, strokes, putts, par, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, score.ID, score.TeamID, score.GameID, score.Hole, score.Strokes, score.Putts, score.Par, score.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert team score: %w", err)
+	}
+
+	log.Info().
+		Str("team_score_id", scoreID).
+		Str("team_id", teamID).
+		Str("game_id", gameID).
+		Int("hole", req.Hole).
+		Int("strokes", req.Strokes).
+		Msg("Team score recorded successfully")
+
+	return score, nil
+}
+
+// UpdateTeamScore updates a scramble team's existing score
+func (s *TeamService) UpdateTeamScore(gameID, teamID string, hole int, req *models.UpdateScoreRequest) (*models.TeamScore, error) {
+	existing, err := s.getTeamScore(gameID, teamID, hole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.scores.validateUpdateScoreRequest(req); err != nil {
+		return nil, err
+	}
+
+	strokes := existing.Strokes
+	putts := existing.Putts
+	if req.Strokes != nil {
+		strokes = *req.Strokes
+	}
+	if req.Putts != nil {
+		putts = *req.Putts
+	}
+
+	if putts > strokes {
+		return nil, errors.NewWithDetails(
+			errors.ErrInvalidPuttCount,
+			"Putt count cannot exceed stroke count",
+			map[string]interface{}{
+				"strokes": strokes,
+				"putts":   putts,
+			},
+		)
+	}
+
+	_, err = s.db.Exec(`
+		UPDATE team_scores
+		SET strokes = ?, putts = ?, updated_at = ?
+		WHERE id = ?
+	`, strokes, putts, time.Now(), existing.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update team score: %w", err)
+	}
+
+	return s.getTeamScore(gameID, teamID, hole)
+}
+
+// GetTeamLeaderboard ranks teams by their counted score to par
+func (s *TeamService) GetTeamLeaderboard(gameID string) (*models.TeamLeaderboard, error) {
+	format, err := s.getTeamFormat(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.calculateTeamCards(gameID, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate team scores: %w", err)
+	}
+
+	leaderboard := &models.TeamLeaderboard{
+		TeamFormat: format,
+		Teams:      make([]models.TeamLeaderboardEntry, 0, len(cards)),
+	}
+	for i, card := range cards {
+		leaderboard.Teams = append(leaderboard.Teams, models.TeamLeaderboardEntry{
+			Position: i + 1,
+			Team: models.TeamSummary{
+				ID:      card.team.ID,
+				Name:    card.team.Name,
+				Players: card.team.Players,
+			},
+			Score:          models.FormatScoreToPar(card.toPar, 0),
+			HolesCompleted: len(card.scores),
+		})
+	}
+
+	return leaderboard, nil
+}
+
+// GetTeamScorecard returns every team's counted score on each hole
+func (s *TeamService) GetTeamScorecard(gameID string) (*models.TeamScorecard, error) {
+	format, err := s.getTeamFormat(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := s.scores.getGameSummary(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	courseInfo, err := (&sideBetStore{db: s.db}).getCourseHoles(game.Course, models.GenderMale)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.calculateTeamCards(gameID, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate team scores: %w", err)
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].team.Position < cards[j].team.Position
+	})
+
+	scorecard := &models.TeamScorecard{
+		Game:       *game,
+		TeamFormat: format,
+		CourseInfo: courseInfo,
+		Teams:      make([]models.ScorecardTeam, 0, len(cards)),
+	}
+	for _, card := range cards {
+		scorecard.Teams = append(scorecard.Teams, models.ScorecardTeam{
+			ID:       card.team.ID,
+			Name:     card.team.Name,
+			Position: card.team.Position,
+			Players:  card.team.Players,
+			Scores:   card.scores,
+			Totals: &models.PlayerStats{
+				HolesCompleted: len(card.scores),
+				CurrentScore:   models.FormatScoreToPar(card.toPar, 0),
+				TotalPutts:     card.putts,
+			},
+		})
+	}
+
+	return scorecard, nil
+}
+
+// teamCard is a team's counted score on each completed hole
+type teamCard struct {
+	team   models.Team
+	scores []models.TeamHoleScore
+	toPar  int
+	putts  int
+}
+
+// calculateTeamCards builds each team's card, best team first. Scramble
+// teams count their recorded team score. Best ball and shamble teams count
+// their low ball once every team member has scored the hole, using net
+// scores when handicaps are enabled.
+func (s *TeamService) calculateTeamCards(gameID string, format models.TeamFormat) ([]*teamCard, error) {
+	teams, err := s.getTeams(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*teamCard, 0, len(teams))
+	for _, team := range teams {
+		cards = append(cards, &teamCard{team: team, scores: []models.TeamHoleScore{}})
+	}
+
+	if format == models.TeamScramble {
+		if err := s.fillScrambleCards(gameID, cards); err != nil {
+			return nil, err
+		}
+	} else if err := s.fillBestBallCards(gameID, cards); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		if cards[i].toPar != cards[j].toPar {
+			return cards[i].toPar < cards[j].toPar
+		}
+		return len(cards[i].scores) > len(cards[j].scores)
+	})
+
+	return cards, nil
+}
+
+// fillScrambleCards adds each team's recorded scramble scores
+func (s *TeamService) fillScrambleCards(gameID string, cards []*teamCard) error {
+	byID := make(map[string]*teamCard, len(cards))
+	for _, card := range cards {
+		byID[card.team.ID] = card
+	}
+
+	rows, err := s.db.Query(`
+		SELECT team_id, hole, strokes, putts, par
+		FROM team_scores
+		WHERE game_id = ?
+		ORDER BY hole
+	`, gameID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID string
+		var hole, strokes, putts, par int
+		if err := rows.Scan(&teamID, &hole, &strokes, &putts, &par); err != nil {
+			return err
+		}
+
+		card, ok := byID[teamID]
+		if !ok {
+			continue
+		}
+		card.scores = append(card.scores, models.TeamHoleScore{
+			Hole:       hole,
+			Par:        par,
+			Strokes:    strokes,
+			ScoreToPar: models.FormatScoreToPar(strokes, par),
+		})
+		card.toPar += strokes - par
+		card.putts += putts
+	}
+
+	return rows.Err()
+}
+
+// fillBestBallCards adds each team's low ball from individual scores
+func (s *TeamService) fillBestBallCards(gameID string, cards []*teamCard) error {
+	scoring, err := s.scores.getGameScoring(gameID)
+	if err != nil {
+		return err
+	}
+
+	rounds, err := s.scores.getPlayerRounds(gameID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]*playerRound, len(rounds))
+	for _, round := range rounds {
+		byID[round.player.ID] = round
+	}
+
+	for _, card := range cards {
+		if len(card.team.Players) == 0 {
+			continue
+		}
+
+		for hole := 1; hole <= roundHoles; hole++ {
+			best := models.TeamHoleScore{Hole: hole}
+			bestToPar := math.MaxInt
+			putts := 0
+			complete := true
+			for _, player := range card.team.Players {
+				round := byID[player.ID]
+				score, ok := round.holes[hole]
+				if !ok {
+					complete = false
+					break
+				}
+
+				strokes := score.strokes
+				if scoring.HandicapEnabled {
+					strokes -= allocatedStrokes(roundedHandicap(&round.handicap), scoring.ranking(player.ID, hole))
+				}
+				// Players on different tees can have different pars, so the
+				// low ball is the best score to par
+				if strokes-score.par < bestToPar {
+					playerID := player.ID
+					bestToPar = strokes - score.par
+					best.Strokes = strokes
+					best.Par = score.par
+					best.PlayerID = &playerID
+					putts = score.putts
+				}
+			}
+			if !complete {
+				continue
+			}
+
+			best.ScoreToPar = models.FormatScoreToPar(best.Strokes, best.Par)
+			card.scores = append(card.scores, best)
+			card.toPar += best.Strokes - best.Par
+			card.putts += putts
+		}
+	}
+
+	return nil
+}
+
+// getTeamFormat loads a game's team format, failing for games without teams
+func (s *TeamService) getTeamFormat(gameID string) (models.TeamFormat, error) {
+	var format sql.NullString
+	err := s.db.QueryRow("SELECT team_format FROM games WHERE id = ?", gameID).Scan(&format)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.ResourceNotFoundError("Game", gameID)
+		}
+		return "", err
+	}
+	if !format.Valid {
+		return "", errors.New(errors.ErrInvalidGameState, "Game does not use teams")
+	}
+	return models.TeamFormat(format.String), nil
+}
+
+// getTeams loads a game's teams with their players in position order
+func (s *TeamService) getTeams(gameID string) ([]models.Team, error) {
+	rows, err := s.db.Query(`
+		SELECT t.id, t.game_id, t.name, t.position, t.created_at,
+		       p.id, p.name, p.handicap
+		FROM teams t
+		LEFT JOIN players p ON p.team_id = t.id
+		WHERE t.game_id = ?
+		ORDER BY t.position, p.position
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	handicaps, err := (&sideBetStore{db: s.db}).getGameHandicaps(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := []models.Team{}
+	for rows.Next() {
+		var team models.Team
+		var playerID, playerName sql.NullString
+		var handicap sql.NullFloat64
+
+		err := rows.Scan(
+			&team.ID,
+			&team.GameID,
+			&team.Name,
+			&team.Position,
+			&team.CreatedAt,
+			&playerID,
+			&playerName,
+			&handicap,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].ID != team.ID {
+			team.Players = []models.PlayerSummary{}
+			teams = append(teams, team)
+		}
+
+		if playerID.Valid {
+			h := handicap.Float64
+			current := &teams[len(teams)-1]
+			player := models.PlayerSummary{
+				ID:       playerID.String,
+				Name:     playerName.String,
+				Handicap: &h,
+			}
+			handicaps.applyToSummary(&player)
+			current.Players = append(current.Players, player)
+		}
+	}
+
+	return teams, rows.Err()
+}
+
+// validateTeamScoreRules checks that a team score can be recorded
+func (s *TeamService) validateTeamScoreRules(gameID, teamID string) error {
+	var status string
+	var format sql.NullString
+	err := s.db.QueryRow("SELECT status, team_format FROM games WHERE id = ?", gameID).Scan(&status, &format)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ResourceNotFoundError("Game", gameID)
+		}
+		return err
+	}
+
+	if status != string(models.GameStatusInProgress) {
+		return errors.BusinessLogicError(
+			errors.ErrGameNotStarted,
+			"Cannot record scores for game that is not in progress",
+			status,
+			string(models.GameStatusInProgress),
+		)
+	}
+
+	if format.String != string(models.TeamScramble) {
+		return errors.New(errors.ErrInvalidGameState, "Team scores are only recorded in scramble games")
+	}
+
+	var count int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM teams WHERE id = ? AND game_id = ?", teamID, gameID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ResourceNotFoundError("Team", teamID)
+	}
+
+	return nil
+}
+
+// getTeamScore loads a team's score for a hole
+func (s *TeamService) getTeamScore(gameID, teamID string, hole int) (*models.TeamScore, error) {
+	var score models.TeamScore
+	var updatedAt sql.NullTime
+
+	err := s.db.QueryRow(`
+		SELECT id, team_id, game_id, hole, strokes, putts, par, created_at, updated_at
+		FROM team_scores
+		WHERE game_id = ? AND team_id = ? AND hole = ?
+	`, gameID, teamID, hole).Scan(
+		&score.ID,
+		&score.TeamID,
+		&score.GameID,
+		&score.Hole,
+		&score.Strokes,
+		&score.Putts,
+		&score.Par,
+		&score.CreatedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ResourceNotFoundError("Team score", fmt.Sprintf("hole %d", hole))
+		}
+		return nil, err
+	}
+
+	if updatedAt.Valid {
+		score.UpdatedAt = &updatedAt.Time
+	}
+	score.ScoreToPar = models.FormatScoreToPar(score.Strokes, score.Par)
+
+	return &score, nil
+}
+
+// validateTeamFormat checks a requested team format against the rest of the
+// game setup. Scramble teams record one score per hole, so there are no
+// individual scores for other formats or side bets to use.
+func validateTeamFormat(format *models.TeamFormat, scoring models.ScoringFormat, sideBets []models.SideBetType) error {
+	if format == nil {
+		return nil
+	}
+
+	switch *format {
+	case models.TeamBestBall, models.TeamShamble:
+	case models.TeamScramble:
+		if scoring != models.ScoringStrokePlay {
+			return errors.ValidationError("scoring_format", string(scoring), "must be stroke-play for scramble")
+		}
+		if len(sideBets) > 0 {
+			return errors.ValidationError("side_bets", fmt.Sprintf("%v", sideBets), "not available for scramble")
+		}
+	default:
+		return errors.ValidationErrorWithAllowedValues("team_format", string(*format), []interface{}{
+			models.TeamBestBall,
+			models.TeamScramble,
+			models.TeamShamble,
+		})
+	}
+
+	if scoring == models.ScoringMatchPlay {
+		return errors.ValidationError("team_format", string(*format), "not available with match-play")
+	}
+
+	return nil
+}
+
+// checkTeamPlayers reports whether every player is on a team
+func checkTeamPlayers(players []models.Player) error {
+	var unassigned []string
+	for _, player := range players {
+		if player.TeamID == nil {
+			unassigned = append(unassigned, player.Name)
+		}
+	}
+	if len(unassigned) == 0 {
+		return nil
+	}
+	return errors.NewWithDetails(errors.ErrInvalidGameState, "Every player must be on a team", map[string]interface{}{
+		"unassigned_players": unassigned,
+	})
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"math"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// Tiebreak labels for a win that wasn't decided by a card playoff
+const (
+	tiebreakShared  = "shared"
+	tiebreakPlayoff = "playoff"
+)
+
+// cardPlayoffSegments are the closing stretches compared in turn by a card
+// playoff, with the share of each player's handicap taken off for net results
+var cardPlayoffSegments = []struct {
+	holes         int
+	label         string
+	handicapShare float64
+}{
+	{9, "last 9", 1.0 / 2},
+	{6, "last 6", 1.0 / 3},
+	{3, "last 3", 1.0 / 6},
+	{1, "last hole", 1.0 / 18},
+}
+
+// validateTiebreak resolves a requested tiebreak policy. A card playoff is
+// the default.
+func validateTiebreak(policy models.TiebreakPolicy) (models.TiebreakPolicy, error) {
+	switch policy {
+	case "":
+		return models.TiebreakCardPlayoff, nil
+	case models.TiebreakCardPlayoff, models.TiebreakShared, models.TiebreakPlayoff:
+		return policy, nil
+	default:
+		return "", errors.ValidationErrorWithAllowedValues("tiebreak", string(policy), []interface{}{
+			models.TiebreakCardPlayoff,
+			models.TiebreakShared,
+			models.TiebreakPlayoff,
+		})
+	}
+}
+
+// roundResult is a player's total for a result, with the hole by hole
+// results a card playoff compares
+type roundResult struct {
+	playerID       string
+	total          float64
+	holesCompleted int
+	holes          map[int]float64 // Strokes to par, or points
+	handicap       float64         // Taken off in shares for net card playoffs
+}
+
+// firstPlace returns the players tied for first: the best total, then the
+// most holes completed. Players without a hole are left out.
+func firstPlace(results []roundResult, highWins bool) []roundResult {
+	var leaders []roundResult
+	for _, result := range results {
+		if result.holesCompleted == 0 {
+			continue
+		}
+		if len(leaders) == 0 {
+			leaders = append(leaders, result)
+			continue
+		}
+
+		leader := leaders[0]
+		better := result.total < leader.total
+		if highWins {
+			better = result.total > leader.total
+		}
+		switch {
+		case better || (result.total == leader.total && result.holesCompleted > leader.holesCompleted):
+			leaders = []roundResult{result}
+		case result.total == leader.total && result.holesCompleted == leader.holesCompleted:
+			leaders = append(leaders, result)
+		}
+	}
+	return leaders
+}
+
+// decideWinner names the winner from the players tied for first under the
+// game's tiebreak policy. A playoff policy adds the tie to the results and
+// names no winner until the playoff's winner is entered.
+func decideWinner(result, score string, leaders []roundResult, policy models.TiebreakPolicy, highWins bool, results *models.FinalResults) *models.Winner {
+	if len(leaders) == 0 {
+		return nil
+	}
+
+	winner := &models.Winner{PlayerID: leaders[0].playerID, Score: score}
+	if len(leaders) == 1 {
+		return winner
+	}
+
+	switch policy {
+	case models.TiebreakPlayoff:
+		results.Playoffs = append(results.Playoffs, models.Playoff{
+			Result:    result,
+			Score:     score,
+			PlayerIDs: roundResultIDs(leaders),
+		})
+		return nil
+	case models.TiebreakCardPlayoff:
+		for _, segment := range cardPlayoffSegments {
+			leaders = cardPlayoffLeaders(leaders, segment.holes, segment.handicapShare, highWins)
+			if len(leaders) == 1 {
+				winner.PlayerID = leaders[0].playerID
+				winner.Tiebreak = segment.label
+				return winner
+			}
+		}
+	}
+
+	// Still level, so the win is shared
+	ids := roundResultIDs(leaders)
+	winner.PlayerID = ids[0]
+	winner.TiedWith = ids[1:]
+	winner.Tiebreak = tiebreakShared
+	return winner
+}
+
+// cardPlayoffLeaders returns the players with the best result over the last
+// holes of the round, less their share of handicap
+func cardPlayoffLeaders(leaders []roundResult, holes int, handicapShare float64, highWins bool) []roundResult {
+	totals := make([]float64, len(leaders))
+	best := 0.0
+	for i, leader := range leaders {
+		for hole := roundHoles - holes + 1; hole <= roundHoles; hole++ {
+			totals[i] += leader.holes[hole]
+		}
+		totals[i] -= leader.handicap * handicapShare
+
+		if i == 0 || (highWins && totals[i] > best) || (!highWins && totals[i] < best) {
+			best = totals[i]
+		}
+	}
+
+	var remaining []roundResult
+	for i, leader := range leaders {
+		if math.Abs(totals[i]-best) < 1e-9 {
+			remaining = append(remaining, leader)
+		}
+	}
+	return remaining
+}
+
+func roundResultIDs(results []roundResult) []string {
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.playerID
+	}
+	return ids
+}
+
+// setPlayoffWinner records a playoff's winner as the winner of its result
+func setPlayoffWinner(results *models.FinalResults, playoff *models.Playoff, winnerID string) {
+	playoff.WinnerID = &winnerID
+	winner := &models.Winner{
+		PlayerID: winnerID,
+		Score:    playoff.Score,
+		Tiebreak: tiebreakPlayoff,
+	}
+
+	switch playoff.Result {
+	case models.PlayoffOverall:
+		results.OverallWinner = winner
+	case models.PlayoffNet:
+		results.NetWinner = winner
+	case models.PlayoffBestNine:
+		results.BestNineWinner = winner
+	}
+}
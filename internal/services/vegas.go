@@ -0,0 +1,555 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+// vegasPlayers is the number of players in a Vegas game, two teams of two
+const vegasPlayers = 4
+
+// VegasBet plays Las Vegas between two two-player teams. Each team's scores
+// on a hole make a two-digit number, low score first, and the team with
+// the lower number wins the difference in points.
+type VegasBet struct {
+	sideBetStore
+	pointValue float64
+}
+
+// NewVegasBet creates the Vegas side bet with the default dollar value of a point
+func NewVegasBet(db *sql.DB, pointValue float64) *VegasBet {
+	return &VegasBet{
+		sideBetStore: sideBetStore{db: db},
+		pointValue:   pointValue,
+	}
+}
+
+// Type returns the Vegas side bet type
+func (s *VegasBet) Type() models.SideBetType {
+	return models.SideBetVegas
+}
+
+// DefaultStake returns the configured dollar value of one point
+func (s *VegasBet) DefaultStake() models.BetStake {
+	value := s.pointValue
+	return models.BetStake{Value: &value}
+}
+
+// CheckStart requires exactly four players taking part
+func (s *VegasBet) CheckStart(gameID string, players []models.Player) error {
+	if len(players) != vegasPlayers {
+		return vegasPlayerCountError(len(players))
+	}
+	return nil
+}
+
+// InitializePlayer returns an empty Vegas calculation
+func (s *VegasBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.VegasCalculationData{}, nil
+}
+
+// ApplyScore recalculates the points and reports the scored hole's result
+// once both teams have finished it
+func (s *VegasBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	standings, err := s.calculateVegas(gameID)
+	if err != nil || standings == nil {
+		return err
+	}
+	if err := s.saveSideBetCalculations(s.db, gameID, vegasCalculations(standings, false)); err != nil {
+		return err
+	}
+
+	for i := range standings.Holes {
+		if standings.Holes[i].Hole == score.Hole {
+			updates.Vegas = &standings.Holes[i]
+		}
+	}
+
+	return nil
+}
+
+// Recalculate rebuilds the stored Vegas results
+func (s *VegasBet) Recalculate(gameID string) error {
+	standings, err := s.calculateVegas(gameID)
+	if err != nil || standings == nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, vegasCalculations(standings, false))
+}
+
+// Standings returns each hole's numbers and the running points
+func (s *VegasBet) Standings(gameID string) (interface{}, error) {
+	if _, err := s.getGameForSideBet(gameID, models.SideBetVegas); err != nil {
+		return nil, err
+	}
+
+	standings, err := s.calculateVegas(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if standings == nil {
+		return nil, vegasNotSetUpError()
+	}
+
+	return standings, nil
+}
+
+// Finalize adds the Vegas settlement to the final results
+func (s *VegasBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateVegas(gameID)
+	if err != nil || standings == nil {
+		return nil, err
+	}
+
+	results.Vegas = &models.VegasSettlement{
+		Leader:     standings.Leader,
+		Points:     standings.Points,
+		Settlement: standings.Settlement,
+	}
+	return vegasCalculations(standings, true), nil
+}
+
+// Settle returns what each player wins or pays on the final points
+func (s *VegasBet) Settle(gameID string) ([]models.SettlementAmount, error) {
+	standings, err := s.calculateVegas(gameID)
+	if err != nil || standings == nil {
+		return []models.SettlementAmount{}, err
+	}
+
+	amounts := make([]models.SettlementAmount, 0, len(standings.Settlement))
+	for _, payout := range standings.Settlement {
+		amounts = append(amounts, models.SettlementAmount{Player: payout.Player, Amount: payout.Amount})
+	}
+	return amounts, nil
+}
+
+// Actions exposes the setup endpoint
+func (s *VegasBet) Actions() []SideBetAction {
+	return []SideBetAction{
+		{
+			Method: http.MethodPost,
+			Path:   "/setup",
+			Handle: s.Setup,
+		},
+	}
+}
+
+// Setup sets the two teams and the dollar value of a point
+func (s *VegasBet) Setup(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetVegas)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status == models.GameStatusCompleted {
+		return nil, errors.New(errors.ErrGameAlreadyCompleted, "Cannot change Vegas setup after the game is completed")
+	}
+
+	var req models.VegasSetupRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetVegas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateVegasSides(req.Sides, players); err != nil {
+		return nil, err
+	}
+
+	stake, err := s.getBetStake(gameID, models.SideBetVegas, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+	pointValue := stake.value
+	if req.PointValue != nil {
+		if err := checkStakeAmount("point_value", *req.PointValue); err != nil {
+			return nil, err
+		}
+		pointValue = *req.PointValue
+		if err := s.setBetStakeValue(gameID, models.SideBetVegas, pointValue); err != nil {
+			return nil, fmt.Errorf("failed to save vegas point value: %w", err)
+		}
+	}
+
+	sideA, err := json.Marshal(req.Sides[0])
+	if err != nil {
+		return nil, err
+	}
+	sideB, err := json.Marshal(req.Sides[1])
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO vegas_settings (game_id, side_a, side_b, point_value, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(game_id) DO UPDATE SET
+			side_a = excluded.side_a,
+			side_b = excluded.side_b,
+			point_value = excluded.point_value,
+			updated_at = excluded.updated_at
+	`, gameID, string(sideA), string(sideB), pointValue, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to save vegas setup: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save vegas calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// vegasSettings is the stored or default configuration of a game's Vegas
+type vegasSettings struct {
+	sides      [2][]string
+	pointValue float64
+}
+
+// calculateVegas plays out the points from the recorded scores. Holes count
+// once all four players have scored them, in hole order. Returns nil
+// standings when the teams can't be worked out.
+func (s *VegasBet) calculateVegas(gameID string) (*models.VegasStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetVegas)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetVegas)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getVegasSettings(gameID, players)
+	if err != nil || settings == nil {
+		return nil, err
+	}
+
+	courses, err := s.getPlayerCourses(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.PlayerSummary, len(players))
+	for _, player := range players {
+		byID[player.ID] = player
+	}
+
+	standings := &models.VegasStandings{
+		BetType:         models.SideBetVegas,
+		Status:          game.Status,
+		HandicapEnabled: game.HandicapEnabled,
+		PointValue:      settings.pointValue,
+		Holes:           []models.VegasHoleResult{},
+	}
+
+	var vegasPlayerIDs []string
+	for i, ids := range settings.sides {
+		side := models.VegasSide{Side: nassauSideName(i)}
+		names := make([]string, 0, len(ids))
+		for _, id := range ids {
+			side.Players = append(side.Players, byID[id])
+			names = append(names, byID[id].Name)
+			vegasPlayerIDs = append(vegasPlayerIDs, id)
+		}
+		side.Name = strings.Join(names, " & ")
+		standings.Sides = append(standings.Sides, side)
+	}
+
+	// Net scoring gives strokes off the lowest handicap in the group
+	strokes := make(map[string]int, len(vegasPlayerIDs))
+	if game.HandicapEnabled {
+		strokes = strokesOffLow(vegasPlayerIDs, byID)
+		standings.Strokes = strokes
+	}
+
+	scores := make(map[string]map[int]models.HoleScore, len(vegasPlayerIDs))
+	for _, id := range vegasPlayerIDs {
+		holeScores, err := s.getPlayerHoleScores(id)
+		if err != nil {
+			return nil, err
+		}
+		scores[id] = make(map[int]models.HoleScore, len(holeScores))
+		for _, score := range holeScores {
+			scores[id][score.Hole] = score
+		}
+	}
+
+	running := 0
+	for hole := 1; hole <= roundHoles; hole++ {
+		var net, toPar [2][]int
+		complete := true
+		for i, ids := range settings.sides {
+			for _, id := range ids {
+				score, ok := scores[id][hole]
+				if !ok {
+					complete = false
+					break
+				}
+				netStrokes := score.Strokes - allocatedStrokes(strokes[id], courses[id].ranking(hole))
+				net[i] = append(net[i], netStrokes)
+				toPar[i] = append(toPar[i], netStrokes-score.Par)
+			}
+		}
+		if !complete {
+			break
+		}
+		standings.Thru = hole
+
+		// A birdie by one team flips the other team's number, unless both
+		// birdie. Each player's birdie is against the par on their own card.
+		birdie := [2]bool{
+			min(toPar[0][0], toPar[0][1]) < 0,
+			min(toPar[1][0], toPar[1][1]) < 0,
+		}
+		result := models.VegasHoleResult{Hole: hole, Winner: nassauHalved}
+		result.SideA = vegasNumber(net[0][0], net[0][1], birdie[1] && !birdie[0])
+		result.SideB = vegasNumber(net[1][0], net[1][1], birdie[0] && !birdie[1])
+		switch {
+		case birdie[1] && !birdie[0]:
+			flipped := nassauSideA
+			result.Flipped = &flipped
+		case birdie[0] && !birdie[1]:
+			flipped := nassauSideB
+			result.Flipped = &flipped
+		}
+
+		switch {
+		case result.SideA < result.SideB:
+			result.Winner = nassauSideA
+			result.Points = result.SideB - result.SideA
+			running += result.Points
+		case result.SideB < result.SideA:
+			result.Winner = nassauSideB
+			result.Points = result.SideA - result.SideB
+			running -= result.Points
+		}
+		result.Running = running
+		standings.Holes = append(standings.Holes, result)
+	}
+
+	if running != 0 {
+		leader := nassauSideA
+		if running < 0 {
+			leader = nassauSideB
+		}
+		standings.Leader = &leader
+	}
+	standings.Points = abs(running)
+
+	// Each player on the leading team collects the margin from their opponent
+	for i, ids := range settings.sides {
+		amount := float64(running) * settings.pointValue
+		if i == 1 {
+			amount = -amount
+		}
+		for _, id := range ids {
+			standings.Settlement = append(standings.Settlement, models.VegasPayout{
+				Player: byID[id],
+				Amount: roundCurrency(amount),
+			})
+		}
+	}
+
+	return standings, nil
+}
+
+// vegasNumber combines a team's two scores into its Vegas number, low
+// score first. A flipped number puts the high score first, and so does a
+// score of 10 or more, so a 4 and a 10 make 104.
+func vegasNumber(first, second int, flipped bool) int {
+	low, high := min(first, second), max(first, second)
+	if flipped || high >= 10 {
+		return high*10 + low
+	}
+	return low*10 + high
+}
+
+// getVegasSettings loads the game's Vegas setup. Without one, the game's
+// two teams play each other when it has them, and otherwise the first two
+// players in tee order play the last two. Returns nil when neither applies.
+func (s *VegasBet) getVegasSettings(gameID string, players []models.PlayerSummary) (*vegasSettings, error) {
+	stake, err := s.getBetStake(gameID, models.SideBetVegas, s.DefaultStake())
+	if err != nil {
+		return nil, err
+	}
+
+	var sideA, sideB string
+	settings := &vegasSettings{}
+
+	err = s.db.QueryRow(`
+		SELECT side_a, side_b, point_value
+		FROM vegas_settings
+		WHERE game_id = ?
+	`, gameID).Scan(&sideA, &sideB, &settings.pointValue)
+	if err == sql.ErrNoRows {
+		if len(players) != vegasPlayers {
+			return nil, nil
+		}
+		settings.pointValue = stake.value
+		settings.sides = [2][]string{{players[0].ID, players[1].ID}, {players[2].ID, players[3].ID}}
+
+		teams, err := s.getVegasTeams(gameID)
+		if err != nil {
+			return nil, err
+		}
+		if len(teams) == 2 && validateVegasSides(teams, players) == nil {
+			settings.sides = [2][]string{teams[0], teams[1]}
+		}
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Games that predate stakes keep the point value from their setup
+	if stake.stored {
+		settings.pointValue = stake.value
+	}
+
+	if err := json.Unmarshal([]byte(sideA), &settings.sides[0]); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(sideB), &settings.sides[1]); err != nil {
+		return nil, err
+	}
+
+	// Sides referring to removed players need setting up again
+	if validateVegasSides(settings.sides[:], players) != nil {
+		return nil, nil
+	}
+
+	return settings, nil
+}
+
+// getVegasTeams loads the player IDs on each of the game's teams in team order
+func (s *VegasBet) getVegasTeams(gameID string) ([][]string, error) {
+	rows, err := s.db.Query(`
+		SELECT p.team_id, p.id
+		FROM players p
+		JOIN teams t ON t.id = p.team_id
+		WHERE p.game_id = ? AND `+sideBetParticipantSQL+`
+		ORDER BY t.position, p.position
+	`, gameID, models.SideBetVegas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams [][]string
+	lastTeam := ""
+	for rows.Next() {
+		var teamID, playerID string
+		if err := rows.Scan(&teamID, &playerID); err != nil {
+			return nil, err
+		}
+		if teamID != lastTeam {
+			teams = append(teams, nil)
+			lastTeam = teamID
+		}
+		teams[len(teams)-1] = append(teams[len(teams)-1], playerID)
+	}
+
+	return teams, rows.Err()
+}
+
+// vegasCalculations builds each player's stored Vegas points and winnings
+func vegasCalculations(standings *models.VegasStandings, final bool) []sideBetCalculation {
+	sides := make(map[string]string)
+	for _, side := range standings.Sides {
+		for _, player := range side.Players {
+			sides[player.ID] = side.Side
+		}
+	}
+
+	payouts := append([]models.VegasPayout{}, standings.Settlement...)
+	sort.SliceStable(payouts, func(i, j int) bool {
+		return payouts[i].Amount > payouts[j].Amount
+	})
+
+	calculations := make([]sideBetCalculation, 0, len(payouts))
+	leading := standings.Leader != nil
+	for _, payout := range payouts {
+		side := sides[payout.Player.ID]
+		points := 0
+		position := 1
+		if leading {
+			points = standings.Points
+			if side != *standings.Leader {
+				points = -points
+				position = 2
+			}
+		}
+
+		data := models.VegasCalculationData{
+			Side:   side,
+			Points: points,
+			Amount: payout.Amount,
+		}
+		isWinner := final && points > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: payout.Player.ID,
+			betType:  models.SideBetVegas,
+			data:     data,
+			position: position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+
+	return calculations
+}
+
+// validateVegasSides checks for two sides of two distinct players from the game
+func validateVegasSides(sides [][]string, players []models.PlayerSummary) error {
+	if len(sides) != 2 {
+		return errors.ValidationError("sides", fmt.Sprintf("%d sides", len(sides)), "must contain exactly two sides")
+	}
+	if len(sides[0]) != 2 || len(sides[1]) != 2 {
+		return errors.ValidationError("sides", fmt.Sprintf("%d vs %d", len(sides[0]), len(sides[1])), "each side must have two players")
+	}
+
+	inGame := make(map[string]bool, len(players))
+	for _, player := range players {
+		inGame[player.ID] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, side := range sides {
+		for _, id := range side {
+			if !inGame[id] {
+				return errors.ValidationError("sides", id, "must be playing this side bet")
+			}
+			if seen[id] {
+				return errors.ValidationError("sides", id, "a player can only be on one side")
+			}
+			seen[id] = true
+		}
+	}
+
+	return nil
+}
+
+// vegasNotSetUpError is returned when a Vegas game has no teams for its players
+func vegasNotSetUpError() error {
+	return errors.New(errors.ErrInvalidGameState, "Vegas teams must be set up with POST /side-bets/vegas/setup")
+}
+
+// vegasPlayerCountError is returned when a Vegas game doesn't have four players
+func vegasPlayerCountError(players int) error {
+	return errors.NewWithDetails(errors.ErrInvalidGameState, "Vegas requires exactly 4 players", map[string]interface{}{
+		"players":          players,
+		"required_players": vegasPlayers,
+	})
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golf-gamez/internal/models"
+)
+
+func TestVegasNumber(t *testing.T) {
+	tests := []struct {
+		name          string
+		first, second int
+		flipped       bool
+		want          int
+	}{
+		{name: "low score first", first: 4, second: 5, want: 45},
+		{name: "either order", first: 5, second: 4, want: 45},
+		{name: "same score", first: 4, second: 4, want: 44},
+		{name: "flipped", first: 4, second: 5, flipped: true, want: 54},
+		{name: "ten or more", first: 4, second: 10, want: 104},
+		{name: "ten or more either order", first: 10, second: 4, want: 104},
+		{name: "ten or more flipped", first: 4, second: 10, flipped: true, want: 104},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := vegasNumber(tt.first, tt.second, tt.flipped); got != tt.want {
+				t.Errorf("vegasNumber(%d, %d, %t) = %d, want %d", tt.first, tt.second, tt.flipped, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVegasHole(t *testing.T) {
+	// Strokes on the first hole, a par 4, by side a's two players then side b's
+	tests := []struct {
+		name         string
+		strokes      [4]int
+		sideA, sideB int
+		flipped      string // Empty when neither number is flipped
+		winner       string
+		points       int
+	}{
+		{name: "no birdies", strokes: [4]int{4, 5, 5, 5}, sideA: 45, sideB: 55, winner: nassauSideA, points: 10},
+		{name: "birdie flips the other side", strokes: [4]int{3, 5, 4, 5}, sideA: 35, sideB: 54, flipped: nassauSideB, winner: nassauSideA, points: 19},
+		{name: "birdie by side b", strokes: [4]int{4, 5, 3, 6}, sideA: 54, sideB: 36, flipped: nassauSideA, winner: nassauSideB, points: 18},
+		{name: "both sides birdie", strokes: [4]int{3, 5, 3, 6}, sideA: 35, sideB: 36, winner: nassauSideA, points: 1},
+		{name: "ten or more", strokes: [4]int{4, 10, 5, 5}, sideA: 104, sideB: 55, winner: nassauSideB, points: 49},
+		{name: "ten or more against a birdie", strokes: [4]int{4, 10, 3, 5}, sideA: 104, sideB: 35, flipped: nassauSideA, winner: nassauSideB, points: 69},
+		{name: "halved", strokes: [4]int{4, 5, 5, 4}, sideA: 45, sideB: 45, winner: nassauHalved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vegas, gameID := playVegasHole(t, tt.strokes)
+
+			standings, err := vegas.calculateVegas(gameID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(standings.Holes) != 1 {
+				t.Fatalf("got %d holes, want 1", len(standings.Holes))
+			}
+			hole := standings.Holes[0]
+
+			if hole.SideA != tt.sideA || hole.SideB != tt.sideB {
+				t.Errorf("numbers = %d and %d, want %d and %d", hole.SideA, hole.SideB, tt.sideA, tt.sideB)
+			}
+			flipped := ""
+			if hole.Flipped != nil {
+				flipped = *hole.Flipped
+			}
+			if flipped != tt.flipped {
+				t.Errorf("flipped = %q, want %q", flipped, tt.flipped)
+			}
+			if hole.Winner != tt.winner || hole.Points != tt.points {
+				t.Errorf("winner = %s by %d, want %s by %d", hole.Winner, hole.Points, tt.winner, tt.points)
+			}
+		})
+	}
+}
+
+// playVegasHole plays the first hole of a scratch Vegas game, the first two
+// players against the last two
+func playVegasHole(t *testing.T, strokes [4]int) (*VegasBet, string) {
+	t.Helper()
+	db := newTestDB(t)
+
+	vegas := NewVegasBet(db, 0.25)
+	registry := NewSideBetRegistry(vegas)
+	games := NewGameService(db, registry)
+	players := NewPlayerService(db)
+	sideBets := NewSideBetService(db, registry)
+	scores := NewScoreService(db, sideBets)
+
+	game, err := games.CreateGame(&models.CreateGameRequest{
+		Course:   "diamond-run",
+		SideBets: []models.SideBetType{models.SideBetVegas},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var playerIDs []string
+	for _, name := range []string{"Ann", "Bob", "Cat", "Dan"} {
+		handicap := 0.0
+		player, err := players.AddPlayer(game.ID, &models.CreatePlayerRequest{Name: name, Handicap: &handicap})
+		if err != nil {
+			t.Fatal(err)
+		}
+		playerIDs = append(playerIDs, player.ID)
+	}
+
+	body, err := json.Marshal(models.VegasSetupRequest{Sides: [][]string{playerIDs[:2], playerIDs[2:]}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vegas.Setup(game.ID, body); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := games.StartGame(game.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, playerID := range playerIDs {
+		score, err := scores.RecordScore(game.ID, playerID, &models.ScoreRequest{Hole: 1, Strokes: strokes[i], Putts: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if score.Par != 4 {
+			t.Fatalf("hole 1 is a par %d, want a par 4", score.Par)
+		}
+		if _, err := sideBets.UpdateSideBetsForScore(game.ID, playerID, score); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return vegas, game.ID
+}
@@ -91,6 +91,15 @@ func (s *WebSocketService) BroadcastScoreUpdate(gameID, playerID string, hole in
 	})
 }
 
+// BroadcastTeamScoreUpdate broadcasts a team score update
+func (s *WebSocketService) BroadcastTeamScoreUpdate(gameID, teamID string, hole int, score interface{}) {
+	s.BroadcastGameUpdate(gameID, "team_score_update", map[string]interface{}{
+		"team_id": teamID,
+		"hole":    hole,
+		"score":   score,
+	})
+}
+
 // BroadcastLeaderboardUpdate broadcasts a leaderboard update
 func (s *WebSocketService) BroadcastLeaderboardUpdate(gameID string, leaderboard interface{}) {
 	s.BroadcastGameUpdate(gameID, "leaderboard_update", leaderboard)
@@ -0,0 +1,458 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"golf-gamez/internal/models"
+	"golf-gamez/pkg/errors"
+)
+
+const (
+	// wolfPlayers is the number of players a wolf game needs
+	wolfPlayers = 4
+
+	wolfPending = "pending"
+	wolfWon     = "won"
+	wolfLost    = "lost"
+	wolfHalved  = "halved"
+)
+
+// wolfPoints is the points each player earns for a choice's outcome
+var wolfPoints = map[string]struct{ win, loss int }{
+	models.WolfPartner: {win: 2, loss: 3}, // Wolf and partner each, or each opponent
+	models.WolfLone:    {win: 4, loss: 1}, // Lone wolf, or each opponent
+	models.WolfBlind:   {win: 6, loss: 2}, // Blind wolf, or each opponent
+}
+
+// WolfBet is a four-player points game. The wolf rotates through the tee
+// order and on each hole either picks a partner or plays alone against
+// the other three, with the hole decided by best ball.
+type WolfBet struct {
+	sideBetStore
+}
+
+// NewWolfBet creates the wolf side bet
+func NewWolfBet(db *sql.DB) *WolfBet {
+	return &WolfBet{sideBetStore: sideBetStore{db: db}}
+}
+
+// Type returns the wolf side bet type
+func (s *WolfBet) Type() models.SideBetType {
+	return models.SideBetWolf
+}
+
+// CheckStart requires exactly four players taking part
+func (s *WolfBet) CheckStart(gameID string, players []models.Player) error {
+	if len(players) != wolfPlayers {
+		return wolfPlayerCountError(len(players))
+	}
+	return nil
+}
+
+// InitializePlayer returns an empty wolf calculation
+func (s *WolfBet) InitializePlayer(gameID string, player models.Player) (interface{}, error) {
+	return models.WolfCalculationData{}, nil
+}
+
+// ApplyScore recalculates points and reports the scored hole's outcome
+func (s *WolfBet) ApplyScore(gameID, playerID string, score *models.Score, updates *models.SideBetUpdates) error {
+	standings, err := s.calculateWolf(gameID)
+	if err != nil || standings == nil {
+		return err
+	}
+	if err := s.saveSideBetCalculations(s.db, gameID, wolfCalculations(standings.Players, false)); err != nil {
+		return err
+	}
+
+	for i := range standings.Holes {
+		if standings.Holes[i].Hole == score.Hole {
+			updates.Wolf = &standings.Holes[i]
+		}
+	}
+
+	return nil
+}
+
+// Recalculate rebuilds the stored wolf points
+func (s *WolfBet) Recalculate(gameID string) error {
+	standings, err := s.calculateWolf(gameID)
+	if err != nil || standings == nil {
+		return err
+	}
+	return s.saveSideBetCalculations(s.db, gameID, wolfCalculations(standings.Players, false))
+}
+
+// Standings returns point totals and every hole's choice and outcome
+func (s *WolfBet) Standings(gameID string) (interface{}, error) {
+	if _, err := s.getGameForSideBet(gameID, models.SideBetWolf); err != nil {
+		return nil, err
+	}
+
+	standings, err := s.calculateWolf(gameID)
+	if err != nil {
+		return nil, err
+	}
+	if standings == nil {
+		players, err := s.getSideBetPlayers(gameID, models.SideBetWolf)
+		if err != nil {
+			return nil, err
+		}
+		return nil, wolfPlayerCountError(len(players))
+	}
+
+	return standings, nil
+}
+
+// Finalize adds every player's wolf points to the final results
+func (s *WolfBet) Finalize(gameID string, results *models.FinalResults) ([]sideBetCalculation, error) {
+	standings, err := s.calculateWolf(gameID)
+	if err != nil || standings == nil {
+		return nil, err
+	}
+
+	results.Wolf = standings.Players
+	return wolfCalculations(standings.Players, true), nil
+}
+
+// Actions exposes the endpoint for the wolf's choice on each hole
+func (s *WolfBet) Actions() []SideBetAction {
+	return []SideBetAction{
+		{
+			Method: http.MethodPost,
+			Path:   "/choice",
+			Handle: s.Choose,
+		},
+	}
+}
+
+// Choose records whether the hole's wolf takes a partner, goes lone wolf or
+// goes blind wolf. The choice can be changed until a score is entered for
+// the hole.
+func (s *WolfBet) Choose(gameID string, body []byte) (interface{}, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetWolf)
+	if err != nil {
+		return nil, err
+	}
+
+	if game.Status == models.GameStatusCompleted {
+		return nil, errors.New(errors.ErrGameAlreadyCompleted, "Cannot record wolf choices after the game is completed")
+	}
+
+	var req models.WolfChoiceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, errors.New(errors.ErrValidation, "Invalid JSON in request body")
+	}
+
+	if req.Hole < 1 || req.Hole > roundHoles {
+		return nil, errors.ValidationError("hole", fmt.Sprintf("%d", req.Hole), "must be between 1 and 18")
+	}
+
+	if _, ok := wolfPoints[req.Choice]; !ok {
+		return nil, errors.ValidationErrorWithAllowedValues(
+			"choice",
+			req.Choice,
+			[]interface{}{models.WolfPartner, models.WolfLone, models.WolfBlind},
+		)
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetWolf)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) != wolfPlayers {
+		return nil, wolfPlayerCountError(len(players))
+	}
+	wolf := wolfForHole(players, req.Hole)
+
+	var partnerID *string
+	if req.Choice == models.WolfPartner {
+		if req.PartnerID == nil || *req.PartnerID == wolf.ID {
+			return nil, errors.NewWithDetails(errors.ErrMissingRequiredField, "A partner other than the wolf is required", map[string]interface{}{
+				"field": "partner_id",
+			})
+		}
+		found := false
+		for _, player := range players {
+			if player.ID == *req.PartnerID {
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.ValidationError("partner_id", *req.PartnerID, "must be a player in this game")
+		}
+		partnerID = req.PartnerID
+	}
+
+	var scored int
+	err = s.db.QueryRow("SELECT COUNT(*) FROM scores WHERE game_id = ? AND hole = ?", gameID, req.Hole).Scan(&scored)
+	if err != nil {
+		return nil, err
+	}
+	if scored > 0 {
+		return nil, errors.NewWithDetails(errors.ErrInvalidGameState, "The wolf must choose before scores are entered for the hole", map[string]interface{}{
+			"hole": req.Hole,
+		})
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO wolf_choices (game_id, hole, wolf_id, choice, partner_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(game_id, hole) DO UPDATE SET
+			wolf_id = excluded.wolf_id,
+			choice = excluded.choice,
+			partner_id = excluded.partner_id,
+			created_at = excluded.created_at
+	`, gameID, req.Hole, wolf.ID, req.Choice, partnerID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to save wolf choice: %w", err)
+	}
+
+	if err := s.Recalculate(gameID); err != nil {
+		return nil, fmt.Errorf("failed to save wolf calculations: %w", err)
+	}
+
+	return s.Standings(gameID)
+}
+
+// wolfChoice is a stored wolf choice
+type wolfChoice struct {
+	choice    string
+	partnerID *string
+}
+
+// calculateWolf scores every hole all four players have completed. A wolf
+// who never chose before the hole was scored plays it as a lone wolf.
+// Returns nil standings when the game does not have four players.
+func (s *WolfBet) calculateWolf(gameID string) (*models.WolfStandings, error) {
+	game, err := s.getGameForSideBet(gameID, models.SideBetWolf)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := s.getSideBetPlayers(gameID, models.SideBetWolf)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) != wolfPlayers {
+		return nil, nil
+	}
+
+	choices, err := s.getWolfChoices(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.getWolfScores(gameID, game.HandicapEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]models.PlayerSummary, len(players))
+	points := make(map[string]int, len(players))
+	for _, player := range players {
+		byID[player.ID] = player
+	}
+
+	standings := &models.WolfStandings{
+		BetType:         models.SideBetWolf,
+		Status:          game.Status,
+		HandicapEnabled: game.HandicapEnabled,
+		Holes:           make([]models.WolfHoleResult, 0, roundHoles),
+	}
+
+	for hole := 1; hole <= roundHoles; hole++ {
+		wolf := wolfForHole(players, hole)
+		result := models.WolfHoleResult{Hole: hole, Wolf: wolf, Result: wolfPending}
+
+		choice, chosen := choices[hole]
+		if chosen {
+			result.Choice = &choice.choice
+			if choice.partnerID != nil {
+				partner := byID[*choice.partnerID]
+				result.Partner = &partner
+			}
+		}
+
+		complete := true
+		for _, player := range players {
+			if _, ok := scores[player.ID][hole]; !ok {
+				complete = false
+			}
+		}
+		if !complete {
+			if standings.NextHole == nil {
+				next := hole
+				standings.NextHole = &next
+			}
+			standings.Holes = append(standings.Holes, result)
+			continue
+		}
+
+		if !chosen {
+			choice = wolfChoice{choice: models.WolfLone}
+			result.Choice = &choice.choice
+		}
+
+		// Best ball for each side
+		wolfBest, opponentBest := math.MaxInt, math.MaxInt
+		wolfSide := make(map[string]bool, len(players))
+		for _, player := range players {
+			strokes := scores[player.ID][hole]
+			onWolfSide := player.ID == wolf.ID || (choice.partnerID != nil && player.ID == *choice.partnerID)
+			wolfSide[player.ID] = onWolfSide
+			if onWolfSide && strokes < wolfBest {
+				wolfBest = strokes
+			}
+			if !onWolfSide && strokes < opponentBest {
+				opponentBest = strokes
+			}
+		}
+		result.WolfScore = &wolfBest
+		result.OpponentScore = &opponentBest
+
+		table := wolfPoints[choice.choice]
+		result.Points = make(map[string]int)
+		switch {
+		case wolfBest < opponentBest:
+			result.Result = wolfWon
+			for id, onWolfSide := range wolfSide {
+				if onWolfSide {
+					result.Points[id] = table.win
+				}
+			}
+		case opponentBest < wolfBest:
+			result.Result = wolfLost
+			for id, onWolfSide := range wolfSide {
+				if !onWolfSide {
+					result.Points[id] = table.loss
+				}
+			}
+		default:
+			result.Result = wolfHalved
+		}
+		for id, earned := range result.Points {
+			points[id] += earned
+		}
+
+		standings.Holes = append(standings.Holes, result)
+	}
+
+	results := make([]models.WolfResult, 0, len(players))
+	for _, player := range players {
+		results = append(results, models.WolfResult{Player: player, Points: points[player.ID]})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Points > results[j].Points
+	})
+	for i := range results {
+		if i > 0 && results[i].Points == results[i-1].Points {
+			results[i].Position = results[i-1].Position
+			continue
+		}
+		results[i].Position = i + 1
+	}
+	standings.Players = results
+
+	return standings, nil
+}
+
+// wolfForHole returns the wolf for a hole, rotating through the tee order
+func wolfForHole(players []models.PlayerSummary, hole int) models.PlayerSummary {
+	return players[(hole-1)%len(players)]
+}
+
+// getWolfChoices loads the recorded choices keyed by hole
+func (s *WolfBet) getWolfChoices(gameID string) (map[int]wolfChoice, error) {
+	rows, err := s.db.Query(`
+		SELECT hole, choice, partner_id
+		FROM wolf_choices
+		WHERE game_id = ?
+	`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	choices := make(map[int]wolfChoice)
+	for rows.Next() {
+		var hole int
+		var choice wolfChoice
+		var partnerID sql.NullString
+		if err := rows.Scan(&hole, &choice.choice, &partnerID); err != nil {
+			return nil, err
+		}
+		if partnerID.Valid {
+			id := partnerID.String
+			choice.partnerID = &id
+		}
+		choices[hole] = choice
+	}
+
+	return choices, rows.Err()
+}
+
+// getWolfScores loads each player's strokes by hole, net of handicap
+// strokes when handicaps are enabled
+func (s *WolfBet) getWolfScores(gameID string, handicapEnabled bool) (map[string]map[int]int, error) {
+	rows, err := s.db.Query(`
+		SELECT s.player_id, s.hole, s.strokes, s.par, s.effective_score
+		FROM scores s
+		JOIN players p ON p.id = s.player_id
+		WHERE s.game_id = ? AND `+sideBetParticipantSQL+`
+	`, gameID, models.SideBetWolf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := make(map[string]map[int]int)
+	for rows.Next() {
+		var playerID string
+		var hole, strokes, par, effectiveScore int
+		if err := rows.Scan(&playerID, &hole, &strokes, &par, &effectiveScore); err != nil {
+			return nil, err
+		}
+
+		if handicapEnabled {
+			strokes = par + effectiveScore
+		}
+		if scores[playerID] == nil {
+			scores[playerID] = make(map[int]int)
+		}
+		scores[playerID][hole] = strokes
+	}
+
+	return scores, rows.Err()
+}
+
+// wolfCalculations builds the stored wolf points
+func wolfCalculations(results []models.WolfResult, final bool) []sideBetCalculation {
+	calculations := make([]sideBetCalculation, 0, len(results))
+	for _, result := range results {
+		data := models.WolfCalculationData{Points: result.Points}
+		isWinner := final && result.Position == 1 && result.Points > 0
+		calculations = append(calculations, sideBetCalculation{
+			playerID: result.Player.ID,
+			betType:  models.SideBetWolf,
+			data:     data,
+			position: result.Position,
+			final:    final,
+			isWinner: isWinner,
+		})
+	}
+	return calculations
+}
+
+// wolfPlayerCountError is returned when a wolf game doesn't have four players
+func wolfPlayerCountError(players int) error {
+	return errors.NewWithDetails(errors.ErrInvalidGameState, "Wolf requires exactly 4 players", map[string]interface{}{
+		"players":          players,
+		"required_players": wolfPlayers,
+	})
+}
@@ -129,4 +129,39 @@ func GeneratePokerCardID() (string, error) {
 // GeneratePokerHandID generates a unique poker hand ID
 func GeneratePokerHandID() (string, error) {
 	return generateToken("hand_")
-}
\ No newline at end of file
+}
+
+// GeneratePressID generates a unique Nassau press ID
+func GeneratePressID() (string, error) {
+	return generateToken("press_")
+}
+
+// GenerateTeamID generates a unique team ID
+func GenerateTeamID() (string, error) {
+	return generateToken("team_")
+}
+
+// GenerateTeamScoreID generates a unique team score ID
+func GenerateTeamScoreID() (string, error) {
+	return generateToken("tscore_")
+}
+
+// GenerateTransferID generates a unique settlement transfer ID
+func GenerateTransferID() (string, error) {
+	return generateToken("transfer_")
+}
+
+// GenerateGolferID generates a unique golfer ID
+func GenerateGolferID() (string, error) {
+	return generateToken("golfer_")
+}
+
+// GenerateHandicapRoundID generates a unique handicap round ID
+func GenerateHandicapRoundID() (string, error) {
+	return generateToken("hround_")
+}
+
+// GenerateHandicapIndexID generates a unique handicap index revision ID
+func GenerateHandicapIndexID() (string, error) {
+	return generateToken("hindex_")
+}
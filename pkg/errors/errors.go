@@ -23,6 +23,7 @@ const (
 	ErrGameAlreadyCompleted  ErrorCode = "game_already_completed"
 	ErrPlayerLimitExceeded   ErrorCode = "player_limit_exceeded"
 	ErrDuplicatePlayerName   ErrorCode = "duplicate_player_name"
+	ErrDuplicateGolfer       ErrorCode = "duplicate_golfer"
 	ErrScoreAlreadyExists    ErrorCode = "score_already_exists"
 	ErrFutureHole           ErrorCode = "future_hole"
 	ErrInvalidGameState     ErrorCode = "invalid_game_state"
@@ -134,7 +135,7 @@ func (e *APIError) GetHTTPStatus() int {
 		return http.StatusBadRequest
 	case ErrSideBetNotEnabled, ErrInsufficientHoles, ErrCardsAlreadyDealt, ErrInvalidPuttCount, ErrGameNotCompleted:
 		return http.StatusBadRequest
-	case ErrPlayerLimitExceeded, ErrDuplicatePlayerName, ErrScoreAlreadyExists:
+	case ErrPlayerLimitExceeded, ErrDuplicatePlayerName, ErrDuplicateGolfer, ErrScoreAlreadyExists:
 		return http.StatusConflict
 	case ErrResourceNotFound, ErrGameNotFound, ErrPlayerNotFound, ErrScoreNotFound:
 		return http.StatusNotFound